@@ -13,40 +13,73 @@ import (
 var (
 	collaborators string // Comma-separated list of collaborators
 	permission    string
+	teamSpecs     []string
 )
 
 // addCollabCmd represents the add_collab command
 var addCollabCmd = &cobra.Command{
 	Use:   "add-collab",
-	Short: "Add collaborators to a GitHub repository",
-	Long: `This command allows you to add one or more collaborators to a specified GitHub repository.
+	Short: "Add collaborators or teams to a GitHub repository",
+	Long: `This command allows you to add one or more collaborators to a specified GitHub repository,
+and to give org teams access to it with --teams.
 	
 Example usage:
   mycli add-collab --org myorg --repo-name myrepoName --collaborators user1,user2 --permission push
+  mycli add-collab --repo-name myrepoName --teams workshop-admins:maintain,cse:push
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
-		// Convert comma-separated collaborators string into a slice
-		collabList := strings.Split(collaborators, ",")
+		if collaborators == "" && len(teamSpecs) == 0 {
+			log.Fatal("Give --collaborators, --teams or both.")
+		}
+		grants, err := teamGrants()
+		if err != nil {
+			log.Fatal(err)
+		}
 
 		// Initialize the GitHub client using your existing NewClient function
 		client := github.NewClient()
 
-		// Call the AddCollaborators function
-		err := client.AddCollaborators(orgName, repoName, collabList, permission)
-		if err != nil {
-			log.Fatalf("Error adding collaborators: %v", err)
+		if len(grants) > 0 {
+			if err := client.AddTeams(orgName, repoName, grants); err != nil {
+				log.Fatalf("Error adding teams: %v", err)
+			}
+		}
+
+		if collaborators != "" {
+			// Convert comma-separated collaborators string into a slice
+			collabList := strings.Split(collaborators, ",")
+
+			// Call the AddCollaborators function
+			result, err := client.AddCollaborators(orgName, repoName, collabList, permission)
+			if err != nil {
+				log.Fatalf("Error adding collaborators: %v", err)
+			}
+			if len(result.Invited) > 0 {
+				fmt.Printf("%d collaborators added, %d invited; invitations are pending until accepted (see invitations list).\n",
+					len(result.Added), len(result.Invited))
+				return
+			}
 		}
 
 		fmt.Println("All collaborators added successfully.")
 	},
 }
 
+// teamGrants parses --teams.
+func teamGrants() ([]github.TeamGrant, error) {
+	return github.ParseTeamGrants(teamSpecs)
+}
+
+func addTeamsFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&teamSpecs, "teams", nil, "Org teams to give access, as slug:permission separated by commas, e.g. team-a:push,team-b:maintain. The permission defaults to push.")
+}
+
 func init() {
 	rootCmd.AddCommand(addCollabCmd)
-	addCollabCmd.Flags().StringVarP(&orgName, "org", "o", "FortinetCloudCSE", "GitHub repository organization (required)")
+	addOrgFlag(addCollabCmd)
 	addCollabCmd.Flags().StringVarP(&repoName, "repo-name", "r", "", "GitHub repository name (required)")
-	addCollabCmd.Flags().StringVarP(&collaborators, "collaborators", "c", "", "Comma-separated list of collaborators to add (required)")
+	addCollabCmd.Flags().StringVarP(&collaborators, "collaborators", "c", "", "Comma-separated list of collaborators to add")
 	addCollabCmd.Flags().StringVarP(&permission, "permission", "p", "push", "Permission level (pull, push, admin, maintain, triage)")
+	addTeamsFlag(addCollabCmd)
 	addCollabCmd.MarkFlagRequired("repo-name")
-	addCollabCmd.MarkFlagRequired("collaborators")
 }
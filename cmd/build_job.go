@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/robreris/gh-jenkins-cli/jenkins"
+	"github.com/spf13/cobra"
+)
+
+var (
+	buildParams  map[string]string
+	buildTimeout time.Duration
+)
+
+// Exit codes for build-job, one per Jenkins build result.
+var buildExitCodes = map[string]int{
+	"SUCCESS":  0,
+	"FAILURE":  1,
+	"UNSTABLE": 2,
+	"ABORTED":  3,
+}
+
+// buildTimeoutExitCode is build-job's exit code when --timeout runs out.
+const buildTimeoutExitCode = 5
+
+var buildJobCmd = &cobra.Command{
+	Use:   "build-job",
+	Short: "Trigger a Jenkins build and stream its console log",
+	Long: `Trigger a build of a Jenkins job, wait for it to leave the queue and stream its
+console log until it finishes.
+
+The exit code reflects the build result: 0 SUCCESS, 1 FAILURE, 2 UNSTABLE,
+3 ABORTED, 4 any other result. With --timeout, build-job stops waiting once
+the build has been queued or running that long and exits with 5; the build
+itself is left running in Jenkins.
+
+Example usage:
+  gh-jenkins-cli build-job -n my-repo
+  gh-jenkins-cli build-job -n workshops/2026/my-repo -p BRANCH=main -p DEPLOY=false
+  gh-jenkins-cli build-job -n my-repo --timeout 30m
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := jenkins.NewAPIClient()
+
+		build, err := client.TriggerBuild(jenkins.ParseJobPath(jobName), buildParams, buildTimeout, os.Stdout)
+		if errors.Is(err, jenkins.ErrBuildTimeout) {
+			fmt.Fprintln(os.Stderr, "Error building Jenkins job:", err)
+			os.Exit(buildTimeoutExitCode)
+		}
+		if err != nil {
+			log.Fatal("Error building Jenkins job: ", err)
+		}
+
+		fmt.Printf("Jenkins job '%s' build #%d finished: %s\n", jobName, build.Number, build.Result)
+
+		code, ok := buildExitCodes[build.Result]
+		if !ok {
+			code = 4
+		}
+		os.Exit(code)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(buildJobCmd)
+	buildJobCmd.Flags().StringVarP(&jobName, "name", "n", "", "Name of Jenkins job, including any folders (e.g. workshops/2026/my-repo).")
+	buildJobCmd.Flags().StringToStringVarP(&buildParams, "param", "p", nil, "Build parameter as key=value. Repeat for multiple parameters.")
+	buildJobCmd.Flags().DurationVar(&buildTimeout, "timeout", 0, "Stop waiting after this long, e.g. 30m. 0 waits until the build finishes.")
+	buildJobCmd.MarkFlagRequired("name")
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/robreris/gh-jenkins-cli/github"
+	"github.com/spf13/cobra"
+)
+
+var keepClassic bool
+
+var convertProtectionCmd = &cobra.Command{
+	Use:   "convert-protection",
+	Short: "Replace a branch's classic protection with an equivalent repository ruleset",
+	Long: `Read the classic branch protection of a branch, apply the same settings as a
+repository ruleset and then remove the classic protection. Pass --keep-classic
+to leave the classic protection in place, e.g. to compare both before removing it.
+
+Example usage:
+  gh-jenkins-cli convert-protection -r my-repo
+  gh-jenkins-cli convert-protection -r my-repo -b release --keep-classic
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := github.NewClient()
+		if err := client.ConvertToRuleset(orgName, repoName, branchName, keepClassic); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Protection of branch '%s' in repository '%s' converted to a ruleset.\n", branchName, repoName)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(convertProtectionCmd)
+	convertProtectionCmd.Flags().StringVarP(&repoName, "repo-name", "r", "", "GitHub repository name (required)")
+	convertProtectionCmd.Flags().StringVarP(&branchName, "branch", "b", "main", "Branch to convert")
+	convertProtectionCmd.Flags().BoolVar(&keepClassic, "keep-classic", false, "Keep the classic branch protection after creating the ruleset")
+	addOrgFlag(convertProtectionCmd)
+	convertProtectionCmd.MarkFlagRequired("repo-name")
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/robreris/gh-jenkins-cli/jenkins"
+	"github.com/spf13/cobra"
+)
+
+var (
+	copyFrom   string
+	copyTo     string
+	sourceRepo string
+	targetRepo string
+)
+
+var copyJobCmd = &cobra.Command{
+	Use:   "copy-job",
+	Short: "Copy an existing Jenkins job",
+	Long: `Create a new Jenkins job as a copy of an existing one. With --repo, the copy's
+GitHub repo URLs are rewritten to point at a different repo in the same org.
+
+Example usage:
+  gh-jenkins-cli copy-job --from my-repo --to my-repo-variant
+  gh-jenkins-cli copy-job --from my-repo --to workshops/2026/other-repo --repo other-repo
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := jenkins.NewAPIClient()
+		from := jenkins.ParseJobPath(copyFrom)
+		to := jenkins.ParseJobPath(copyTo)
+
+		if err := client.CopyJob(from, to); err != nil {
+			log.Fatal("Error copying Jenkins job: ", err)
+		}
+
+		if targetRepo != "" {
+			oldRepo := sourceRepo
+			if oldRepo == "" {
+				oldRepo = from.Name()
+			}
+
+			config, err := client.GetJobConfig(to)
+			if err != nil {
+				log.Fatal("Error fetching copied job config: ", err)
+			}
+
+			if err := client.UpdateJobConfig(to, jenkins.RetargetRepo(config, oldRepo, targetRepo)); err != nil {
+				log.Fatal("Error pointing copied job at repo: ", err)
+			}
+			fmt.Printf("Jenkins job '%s' now builds repo '%s'.\n", to, targetRepo)
+		}
+
+		fmt.Printf("Jenkins job '%s' copied to '%s' successfully.\n", from, to)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(copyJobCmd)
+	copyJobCmd.Flags().StringVar(&copyFrom, "from", "", "Name of the Jenkins job to copy, including any folders.")
+	copyJobCmd.Flags().StringVar(&copyTo, "to", "", "Name of the new Jenkins job, including any folders. Missing folders are created.")
+	copyJobCmd.Flags().StringVarP(&targetRepo, "repo", "r", "", "GitHub repo the copy should build instead of the source job's repo.")
+	copyJobCmd.Flags().StringVar(&sourceRepo, "source-repo", "", "GitHub repo the source job builds. Defaults to the source job's name.")
+	copyJobCmd.MarkFlagRequired("from")
+	copyJobCmd.MarkFlagRequired("to")
+}
@@ -22,7 +22,19 @@ var createJobCmd = &cobra.Command{
 			log.Fatal("Missing some flags.")
 		}
 
-		if err := client.CreateJob(jobName, configXMLPath); err != nil {
+		job := jenkins.ParseJobPath(jobName)
+		data := jobTemplateData(job)
+
+		config, err := renderJobConfig(configXMLPath, data)
+		if err != nil {
+			log.Fatal("Error rendering Jenkins job config: ", err)
+		}
+
+		if err := checkCredentials(client, job, data.CredentialsID); err != nil {
+			log.Fatal("Error checking Jenkins credentials: ", err)
+		}
+
+		if err := client.CreateJob(job, config); err != nil {
 			log.Fatal("Error creating Jenkins job: ", err)
 		}
 
@@ -30,9 +42,28 @@ var createJobCmd = &cobra.Command{
 	},
 }
 
+// checkCredentials makes sure the credentials a job checks out with exist
+// before the job is created, since a missing ID only fails at build time.
+func checkCredentials(client *jenkins.APIClient, job jenkins.JobPath, credentialsID string) error {
+	if credentialsID == "" {
+		return nil
+	}
+
+	found, err := client.FindCredential(job, credentialsID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("credentials ID '%s' not found in Jenkins; create it with 'credentials create' or choose another with --credentials-id", credentialsID)
+	}
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(createJobCmd)
-	createJobCmd.Flags().StringVarP(&jobName, "name", "n", "", "Name of Jenkins job.")
-	createJobCmd.Flags().StringVarP(&configXMLPath, "config-xml", "c", "jenkins/template-config.xml", "Path to config XML file.")
+	createJobCmd.Flags().StringVarP(&jobName, "name", "n", "", "Name of Jenkins job. Use a slash-separated path (e.g. workshops/2026/my-repo) to create it inside folders.")
+	createJobCmd.Flags().StringVarP(&configXMLPath, "config-xml", "c", "", "Path to config XML template. Defaults to the built-in template.")
+	addJobTemplateFlags(createJobCmd)
+	addJobRepoFlags(createJobCmd)
 	createJobCmd.MarkFlagRequired("name")
 }
@@ -12,40 +12,321 @@ import (
 
 var (
 	jenkinsXMLPath string
+	jenkinsFolder  string
+	jenkinsfile    string
+	enableStages   []string
+	disableStages  []string
 	collabNames    []string
+	noRollback     bool
 )
 
 var createProjectCmd = &cobra.Command{
 	Use:   "create-project",
-	Short: "Create a new project in FortinetCloudCSE org consisting of a GitHub repo and associated Jenkins pipeline",
+	Short: "Create a new project in the GitHub org consisting of a GitHub repo and associated Jenkins pipeline",
+	Long: `Create a Jenkins job and a GitHub repo generated from the template repo, then set
+the repo up: Pages, the Jenkins webhook, README and Jenkinsfile, branch
+protection and collaborators.
+
+Re-running the same command finishes a project an earlier run left partly
+created: each step checks for what is already in place and skips it, or
+updates it if it differs (e.g. a job config that changed). Pass the webhook
+secret again with --webhook-secret or GITHUB_WEBHOOK_SECRET, since Jenkins
+doesn't reveal the one stored on the first run.
+
+If a step fails, the steps this run did are undone in reverse order: the
+webhook and repo are deleted, then the Jenkins job. Anything that already
+existed, and folders created for the job, are kept. Pass --no-rollback to keep
+everything for debugging. A report of which steps ran, were skipped, failed and
+were undone is printed at the end.
+
+Example usage:
+  gh-jenkins-cli create-project -p my-repo -u user1,user2
+  gh-jenkins-cli create-project -p my-repo --jenkins-folder workshops/2026 --no-rollback
+	`,
 	Run: func(cmd *cobra.Command, args []string) {
 
+		job := jenkins.ParseJobPath(jenkinsFolder).Join(repoName)
+
+		protection, err := protectionProfile(cmd)
+		if err != nil {
+			log.Fatal("Error selecting protection profile: ", err)
+		}
+		rulesets, err := useRulesets()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		jenkinsfileContent, err := github.LoadJenkinsfile(jenkinsfile)
+		if err != nil {
+			log.Fatal("Error loading Jenkinsfile: ", err)
+		}
+		jenkinsfileContent, err = github.PrepareJenkinsfile(jenkinsfileContent, enableStages, disableStages)
+		if err != nil {
+			log.Fatal("Error customizing Jenkinsfile: ", err)
+		}
+
 		jClient := jenkins.NewAPIClient()
-		if err := jClient.CreateJob(repoName, jenkinsXMLPath); err != nil {
-			log.Fatal("Error creating Jenkins job: ", err)
+
+		// Validate before creating anything, so a broken Jenkinsfile is never
+		// committed.
+		if err := validateJenkinsfile(jClient, jenkinsfileContent); err != nil {
+			log.Fatal(err)
+		}
+
+		data := jobTemplateData(job)
+		data.Org = orgName
+		config, err := renderJobConfig(jenkinsXMLPath, data)
+		if err != nil {
+			log.Fatal("Error rendering Jenkins job config: ", err)
+		}
+
+		if err := checkCredentials(jClient, job, data.CredentialsID); err != nil {
+			log.Fatal("Error checking Jenkins credentials: ", err)
+		}
+		secret, err := chooseWebhookSecret(jClient)
+		if err != nil {
+			log.Fatal(err)
 		}
-		fmt.Printf("Jenkins job %s successfully created.", repoName)
 
 		ghClient := github.NewClient()
-		repo, err := ghClient.CreateRepo("FortinetCloudCSE", repoName, "UserRepo", private, true)
+		grants, err := teamGrants()
 		if err != nil {
-			fmt.Println("Error creating repository:", err)
-			return
+			log.Fatal(err)
+		}
+		if err := ghClient.ValidateTeams(orgName, github.TeamSlugs(grants)); err != nil {
+			log.Fatal(err)
 		}
-		if err := ghClient.AddCollaborators("FortinetCloudCSE", repoName, collabNames, "push"); err != nil {
-			fmt.Println("Error adding collaborators:", err)
-			return
+		opts := github.CreateRepoOptions{
+			Org:           orgName,
+			Name:          repoName,
+			TemplateOwner: sourceTemplateOwner(),
+			TemplateRepo:  templateRepo,
+			Private:       private,
+			Protection:    protection,
+			UseRulesets:   rulesets,
+			ProtectTags:   protectTags,
+			Jenkinsfile:   jenkinsfileContent,
+			WebhookSecret: secret,
+		}
+
+		var repoURL string
+		steps := projectSteps(jClient, ghClient, job, config, opts, grants, &repoURL)
+		if err := runSteps(steps, !noRollback); err != nil {
+			if noRollback {
+				log.Fatalf("Error creating project: %v\nThe steps that ran were kept (--no-rollback).", err)
+			}
+			log.Fatalf("Error creating project: %v", err)
 		}
 
-		fmt.Printf("Repository '%s' created successfully at %s\n", repo.GetName(), repo.GetHTMLURL())
+		fmt.Printf("Repository '%s' created successfully at %s\n", repoName, repoURL)
 	},
 }
 
+// projectSteps returns the steps that create a project: the Jenkins job
+// first, then the repo and its setup. Each step checks what an earlier run
+// left behind, so re-running create-project finishes a partly created
+// project. The repo's URL is stored in repoURL once it is known.
+func projectSteps(jClient *jenkins.APIClient, ghClient *github.Client, job jenkins.JobPath, config []byte, opts github.CreateRepoOptions, grants []github.TeamGrant, repoURL *string) []step {
+	var (
+		pagesURL      string
+		hook          *github.Webhook
+		missing       []string
+		missingGrants []github.TeamGrant
+	)
+	hookURL := ghClient.JenkinsWebhookURL()
+
+	steps := []step{
+		{
+			name: fmt.Sprintf("Create Jenkins job '%s'", job),
+			check: func() (stepState, error) {
+				exists, err := jClient.JobExists(job)
+				if err != nil || !exists {
+					return stateMissing, err
+				}
+				same, err := sameJobConfig(jClient, job, config)
+				if err != nil || !same {
+					return stateDiffers, err
+				}
+				return stateInPlace, nil
+			},
+			run:      func() error { return jClient.CreateJob(job, config) },
+			converge: func() error { return jClient.UpdateJobConfig(job, config) },
+			undo:     func() error { return jClient.DeleteJob(job) },
+		},
+		{
+			name: fmt.Sprintf("Generate repo '%s/%s' from '%s/%s'", opts.Org, opts.Name, opts.TemplateOwner, opts.TemplateRepo),
+			check: func() (stepState, error) {
+				var err error
+				*repoURL, err = ghClient.FindGeneratedRepo(opts.Org, opts.Name, opts.TemplateOwner, opts.TemplateRepo)
+				if err != nil || *repoURL == "" {
+					return stateMissing, err
+				}
+				return stateInPlace, nil
+			},
+			run: func() error {
+				repo, err := ghClient.GenerateRepoFromTemplate(opts.TemplateOwner, opts.TemplateRepo, opts.Org, opts.Name, opts.Private)
+				if err != nil {
+					return err
+				}
+				*repoURL = repo.GetHTMLURL()
+				return nil
+			},
+			undo: func() error { return ghClient.DeleteRepo(opts.Org, opts.Name) },
+		},
+		{
+			name: "Wait for main branch",
+			run:  func() error { return ghClient.WaitForMainBranch(opts.Org, opts.Name) },
+		},
+		{
+			name: "Enable GitHub Pages",
+			check: func() (stepState, error) {
+				var err error
+				pagesURL, err = ghClient.PagesURL(opts.Org, opts.Name)
+				if err != nil || pagesURL == "" {
+					return stateMissing, err
+				}
+				return stateInPlace, nil
+			},
+			run: func() error {
+				var err error
+				pagesURL, err = ghClient.EnableGitHubPages(opts.Org, opts.Name)
+				return err
+			},
+		},
+		{
+			// Saved as a step so a rolled-back first run doesn't leave a
+			// credential behind that a re-run can't match.
+			name: fmt.Sprintf("Save webhook secret in Jenkins credential '%s'", webhookCredentialsID),
+			check: func() (stepState, error) {
+				exists, err := jClient.CredentialExists(jenkins.CredentialStore{}, webhookCredentialsID)
+				if err != nil || !exists {
+					return stateMissing, err
+				}
+				return stateDiffers, nil
+			},
+			run: func() error {
+				_, err := saveWebhookSecret(jClient, opts.WebhookSecret)
+				return err
+			},
+			undo: func() error { return jClient.DeleteCredential(jenkins.CredentialStore{}, webhookCredentialsID) },
+		},
+		{
+			// An existing hook is updated rather than skipped: GitHub never
+			// returns its secret, so there's no telling whether it's current.
+			name: "Create Jenkins webhook",
+			check: func() (stepState, error) {
+				existing, err := ghClient.FindWebhook(opts.Org, opts.Name, hookURL)
+				if err != nil || existing == nil {
+					return stateMissing, err
+				}
+				return stateDiffers, nil
+			},
+			run: func() error {
+				var err error
+				hook, err = ghClient.CreateWebhook(opts.Org, opts.Name, hookURL, opts.WebhookSecret)
+				return err
+			},
+			undo: func() error { return ghClient.DeleteWebhook(opts.Org, opts.Name, hook.ID) },
+		},
+		{
+			name: "Commit README and Jenkinsfile",
+			check: func() (stepState, error) {
+				readme := github.ReadmeContent(opts.Name, pagesURL, opts.TemplateOwner, opts.TemplateRepo)
+				same, err := ghClient.RepoFilesMatch(opts.Org, opts.Name, readme, opts.Jenkinsfile)
+				if err != nil || !same {
+					return stateMissing, err
+				}
+				return stateInPlace, nil
+			},
+			run: func() error {
+				readme := github.ReadmeContent(opts.Name, pagesURL, opts.TemplateOwner, opts.TemplateRepo)
+				return ghClient.UpdateRepoFiles(opts.Org, opts.Name, readme, opts.Jenkinsfile)
+			},
+		},
+		{
+			name: fmt.Sprintf("Wait for status check '%s'", github.JenkinsStatusCheck),
+			run: func() error {
+				return ghClient.WaitForStatusCheck(opts.Org, opts.Name, "main", github.JenkinsStatusCheck)
+			},
+		},
+		{
+			// Applying a profile replaces whatever protection is in place, so
+			// this step always runs.
+			name: "Protect main branch",
+			run:  func() error { return ghClient.ProtectMainBranch(opts) },
+		},
+	}
+
+	if len(collabNames) > 0 {
+		steps = append(steps, step{
+			name: "Add collaborators",
+			check: func() (stepState, error) {
+				var err error
+				missing, err = ghClient.MissingCollaborators(opts.Org, opts.Name, collabNames)
+				if err != nil || len(missing) > 0 {
+					return stateMissing, err
+				}
+				return stateInPlace, nil
+			},
+			run: func() error {
+				_, err := ghClient.AddCollaborators(opts.Org, opts.Name, missing, "push")
+				return err
+			},
+		})
+	}
+
+	if len(grants) > 0 {
+		steps = append(steps, step{
+			name: "Add teams",
+			check: func() (stepState, error) {
+				var err error
+				missingGrants, err = ghClient.MissingTeamGrants(opts.Org, opts.Name, grants)
+				if err != nil || len(missingGrants) > 0 {
+					return stateMissing, err
+				}
+				return stateInPlace, nil
+			},
+			run: func() error { return ghClient.AddTeams(opts.Org, opts.Name, missingGrants) },
+		})
+	}
+	return steps
+}
+
+// sameJobConfig reports whether a job's config matches config, ignoring
+// formatting.
+func sameJobConfig(client *jenkins.APIClient, job jenkins.JobPath, config []byte) (bool, error) {
+	current, err := client.GetJobConfig(job)
+	if err != nil {
+		return false, err
+	}
+
+	currentXML, err := jenkins.NormalizeXML(current)
+	if err != nil {
+		return false, err
+	}
+	wantXML, err := jenkins.NormalizeXML(config)
+	if err != nil {
+		return false, err
+	}
+	return currentXML == wantXML, nil
+}
+
 func init() {
 	rootCmd.AddCommand(createProjectCmd)
 	createProjectCmd.Flags().StringVarP(&repoName, "project-name", "p", "", "Name of the project/repo")
-	createProjectCmd.Flags().StringVarP(&jenkinsXMLPath, "jenkins-xml", "j", "jenkins/template-config.xml", "Path to Jenkins config XML file.")
+	createProjectCmd.Flags().StringVarP(&jenkinsXMLPath, "jenkins-xml", "j", "", "Path to Jenkins config XML template. Defaults to the built-in template.")
+	createProjectCmd.Flags().StringVar(&jenkinsfile, "jenkinsfile", "", "Path to the Jenkinsfile to commit. Defaults to the built-in Jenkinsfile.")
+	createProjectCmd.Flags().StringArrayVar(&enableStages, "enable-stage", nil, "Name of a Jenkinsfile stage to enable. Repeat for multiple stages; see list-stages.")
+	createProjectCmd.Flags().StringArrayVar(&disableStages, "disable-stage", nil, "Name of a Jenkinsfile stage to disable. Repeat for multiple stages; see list-stages.")
+	addJobTemplateFlags(createProjectCmd)
+	createProjectCmd.Flags().StringVarP(&jenkinsFolder, "jenkins-folder", "f", "", "Jenkins folder path to create the job in (e.g. workshops/2026). Missing folders are created.")
 	createProjectCmd.Flags().StringSliceVarP(&collabNames, "collab-names", "u", []string{}, "GitHub usernames to add as collaborators. Enter each username separated by a comma. e.g. -c user1,user2,user3.")
 	createProjectCmd.Flags().BoolVarP(&private, "private", "r", false, "Make repository private")
+	addOrgFlag(createProjectCmd)
+	addTemplateRepoFlags(createProjectCmd)
+	addProtectionFlags(createProjectCmd)
+	addTeamsFlag(createProjectCmd)
+	addWebhookSecretFlags(createProjectCmd, "webhook-credentials-id")
+	createProjectCmd.Flags().BoolVar(&noRollback, "no-rollback", false, "Keep whatever was created if a step fails, instead of undoing it.")
 	createProjectCmd.MarkFlagRequired("project-name")
 }
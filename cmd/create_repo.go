@@ -2,22 +2,32 @@ package cmd
 
 import (
 	"fmt"
+	"log"
+
 	"github.com/robreris/gh-jenkins-cli/github"
 	"github.com/spf13/cobra"
 )
 
 var (
-	orgName  string
 	repoName string
 	private  bool
 )
 
 var createRepoCmd = &cobra.Command{
 	Use:   "create-repo",
-	Short: "Create a new repo in FortinetCloudCSE org",
+	Short: "Create a new repo in the GitHub org from the template repo",
 	Run: func(cmd *cobra.Command, args []string) {
+		protection, err := protectionProfile(cmd)
+		if err != nil {
+			log.Fatal("Error selecting protection profile: ", err)
+		}
+		rulesets, err := useRulesets()
+		if err != nil {
+			log.Fatal(err)
+		}
+
 		client := github.NewClient()
-		repo, err := client.CreateRepo("FortinetCloudCSE", repoName, "UserRepo", private, false)
+		repo, err := client.CreateRepo(createRepoOptions(protection, rulesets))
 		if err != nil {
 			fmt.Println("Error creating repository:", err)
 			return
@@ -26,9 +36,26 @@ var createRepoCmd = &cobra.Command{
 	},
 }
 
+// createRepoOptions returns the options for create-repo from its flags.
+func createRepoOptions(protection github.ProtectionProfile, rulesets bool) github.CreateRepoOptions {
+	return github.CreateRepoOptions{
+		Org:           orgName,
+		Name:          repoName,
+		TemplateOwner: templateOwner,
+		TemplateRepo:  templateRepo,
+		Private:       private,
+		Protection:    protection,
+		UseRulesets:   rulesets,
+		ProtectTags:   protectTags,
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(createRepoCmd)
 	createRepoCmd.Flags().StringVarP(&repoName, "name", "n", "", "Name of the repo")
 	createRepoCmd.Flags().BoolVarP(&private, "private", "p", false, "Make repository private")
+	addOrgFlag(createRepoCmd)
+	addTemplateRepoFlags(createRepoCmd)
+	addProtectionFlags(createRepoCmd)
 	createRepoCmd.MarkFlagRequired("name")
 }
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/robreris/gh-jenkins-cli/github"
+)
+
+// create-repo must look up the org rulesets under the name protect-branch
+// --org-ruleset gave them, including when --template-owner falls back to --org.
+func TestCreateRepoOrgRulesetName(t *testing.T) {
+	tests := []struct {
+		name  string
+		owner string
+		want  string
+	}{
+		{name: "owner defaults to org", owner: "", want: "FortinetCloudCSE/UserRepo"},
+		{name: "explicit owner", owner: "other-owner", want: "other-owner/UserRepo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgName, templateOwner, templateRepo = "FortinetCloudCSE", tt.owner, "UserRepo"
+
+			if got := orgRulesetName(); got != tt.want {
+				t.Errorf("protect-branch org ruleset name = %q, want %q", got, tt.want)
+			}
+			if got := createRepoOptions(github.ProtectionProfile{}, false).OrgRulesetName(); got != tt.want {
+				t.Errorf("create-repo org ruleset name = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
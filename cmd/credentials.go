@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/robreris/gh-jenkins-cli/jenkins"
+	"github.com/spf13/cobra"
+)
+
+var (
+	credStoreFolder string
+	credDomain      string
+	credType        string
+	credID          string
+	credDescription string
+	credUsername    string
+	credPassword    string
+	credSecret      string
+)
+
+var credentialsCmd = &cobra.Command{
+	Use:   "credentials",
+	Short: "Manage the Jenkins credentials used by generated jobs",
+	Long: `Manage Jenkins credentials through the credentials plugin. Commands work on the
+system store unless --folder selects a folder's store, and on the global domain
+unless --domain is given.`,
+}
+
+var credentialsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the credentials in a store",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := jenkins.NewAPIClient()
+
+		creds, err := client.ListCredentials(credentialStore())
+		if err != nil {
+			log.Fatal("Error listing credentials: ", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTYPE\tNAME\tDESCRIPTION")
+		for _, c := range creds {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.ID, c.TypeName, c.DisplayName, c.Description)
+		}
+		w.Flush()
+	},
+}
+
+var credentialsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a username/password or secret text credential",
+	Long: `Create a credential. Pass "-" as --password or --secret to read it from stdin.
+
+Example usage:
+  gh-jenkins-cli credentials create --id jenkins-git --username bot --password -
+  gh-jenkins-cli credentials create --type secret-text --id slack-token --secret - --folder workshops
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := jenkins.NewAPIClient()
+		store := credentialStore()
+
+		if err := client.CreateCredential(store, credentialSpec()); err != nil {
+			log.Fatal("Error creating credential: ", err)
+		}
+		fmt.Printf("Credential '%s' created in '%s'.\n", credID, store)
+	},
+}
+
+var credentialsUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Replace an existing credential, e.g. after rotating it",
+	Long: `Replace an existing credential. Pass "-" as --password or --secret to read it
+from stdin.
+
+Example usage:
+  gh-jenkins-cli credentials update --id jenkins-git --username bot --password -
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := jenkins.NewAPIClient()
+		store := credentialStore()
+
+		if err := client.UpdateCredential(store, credentialSpec()); err != nil {
+			log.Fatal("Error updating credential: ", err)
+		}
+		fmt.Printf("Credential '%s' updated in '%s'.\n", credID, store)
+	},
+}
+
+func credentialStore() jenkins.CredentialStore {
+	return jenkins.CredentialStore{Folder: jenkins.ParseJobPath(credStoreFolder), Domain: credDomain}
+}
+
+// credentialSpec builds the credential from the flags, reading secrets from
+// stdin where requested.
+func credentialSpec() jenkins.CredentialSpec {
+	spec := jenkins.CredentialSpec{
+		Type:        jenkins.CredentialType(credType),
+		ID:          credID,
+		Description: credDescription,
+		Username:    credUsername,
+	}
+
+	var err error
+	switch spec.Type {
+	case jenkins.UsernamePassword:
+		if credUsername == "" || credPassword == "" {
+			log.Fatal("--username and --password are required for username-password credentials.")
+		}
+		spec.Password, err = readSecret(credPassword)
+	case jenkins.SecretText:
+		if credSecret == "" {
+			log.Fatal("--secret is required for secret-text credentials.")
+		}
+		spec.Secret, err = readSecret(credSecret)
+	default:
+		log.Fatalf("Invalid --type %q: use username-password or secret-text", credType)
+	}
+	if err != nil {
+		log.Fatal("Error reading secret: ", err)
+	}
+	return spec
+}
+
+func addCredentialStoreFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&credStoreFolder, "folder", "f", "", "Use the credentials store of this Jenkins folder instead of the system store.")
+	cmd.Flags().StringVar(&credDomain, "domain", "", "Credentials domain. Defaults to the global domain.")
+}
+
+func addCredentialSpecFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&credType, "type", "t", string(jenkins.UsernamePassword), "Credential type: username-password or secret-text.")
+	cmd.Flags().StringVar(&credID, "id", "", "Credential ID referenced by jobs.")
+	cmd.Flags().StringVarP(&credDescription, "description", "d", "", "Credential description.")
+	cmd.Flags().StringVarP(&credUsername, "username", "u", "", "Username for username-password credentials.")
+	cmd.Flags().StringVarP(&credPassword, "password", "p", "", `Password for username-password credentials, or "-" to read it from stdin.`)
+	cmd.Flags().StringVarP(&credSecret, "secret", "s", "", `Secret for secret-text credentials, or "-" to read it from stdin.`)
+	cmd.MarkFlagRequired("id")
+}
+
+func init() {
+	rootCmd.AddCommand(credentialsCmd)
+	credentialsCmd.AddCommand(credentialsListCmd, credentialsCreateCmd, credentialsUpdateCmd)
+
+	addCredentialStoreFlags(credentialsListCmd)
+
+	addCredentialStoreFlags(credentialsCreateCmd)
+	addCredentialSpecFlags(credentialsCreateCmd)
+
+	addCredentialStoreFlags(credentialsUpdateCmd)
+	addCredentialSpecFlags(credentialsUpdateCmd)
+}
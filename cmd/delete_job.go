@@ -13,7 +13,7 @@ var deleteJobCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		client := jenkins.NewAPIClient()
 
-		if err := client.DeleteJob(jobName); err != nil {
+		if err := client.DeleteJob(jenkins.ParseJobPath(jobName)); err != nil {
 			log.Fatal("Error deleting Jenkins job: ", err)
 		}
 
@@ -23,6 +23,6 @@ var deleteJobCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(deleteJobCmd)
-	deleteJobCmd.Flags().StringVarP(&jobName, "name", "n", "", "Name of Jenkins job.")
+	deleteJobCmd.Flags().StringVarP(&jobName, "name", "n", "", "Name of Jenkins job, including any folders (e.g. workshops/2026/my-repo).")
 	deleteJobCmd.MarkFlagRequired("name")
 }
@@ -25,13 +25,22 @@ var deleteProjectCmd = &cobra.Command{
 		}
 
 		jClient := jenkins.NewAPIClient()
-		if err := jClient.DeleteJob(jobName); err != nil {
-			log.Fatalf("Error deleting Jenkins job '%s': %v", jobName, err)
+		job, jobType, err := jClient.ProjectJob(jenkins.ParseJobPath(jobName))
+		if err != nil {
+			log.Fatalf("Error looking up Jenkins job '%s': %v", jobName, err)
+		}
+
+		if err := jClient.DeleteJob(job); err != nil {
+			log.Fatalf("Error deleting Jenkins job '%s': %v", job, err)
+		}
+		if jobType != "" {
+			fmt.Printf("Jenkins %s job '%s' deleted successfully.\n", jobType, job)
+		} else {
+			fmt.Printf("Jenkins job '%s' deleted successfully.\n", job)
 		}
-		fmt.Printf("Jenkins job '%s' deleted successfully.\n", jobName)
 
 		ghClient := github.NewClient()
-		if err := ghClient.DeleteRepo("FortinetCloudCSE", repoName); err != nil {
+		if err := ghClient.DeleteRepo(orgName, repoName); err != nil {
 			log.Fatalf("Error deleting repository '%s': %v", repoName, err)
 		}
 		fmt.Printf("Repository '%s' deleted successfully.\n", repoName)
@@ -42,6 +51,7 @@ func init() {
 	rootCmd.AddCommand(deleteProjectCmd)
 
 	deleteProjectCmd.Flags().StringVarP(&repoName, "project-name", "p", "", "Name of the project/repo to delete.")
-	deleteProjectCmd.Flags().StringVarP(&jenkinsJob, "jenkins-job", "j", "", "Name of the Jenkins job to delete. Defaults to the project name.")
+	deleteProjectCmd.Flags().StringVarP(&jenkinsJob, "jenkins-job", "j", "", "Name or folder path (e.g. workshops/2026/my-job) of the Jenkins job to delete. Defaults to the project name.")
+	addOrgFlag(deleteProjectCmd)
 	deleteProjectCmd.MarkFlagRequired("project-name")
 }
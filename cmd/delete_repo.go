@@ -8,10 +8,10 @@ import (
 
 var deleteRepoCmd = &cobra.Command{
 	Use:   "delete-repo",
-	Short: "Delete an existing repo in FortinetCloudCSE org",
+	Short: "Delete an existing repo in the GitHub org",
 	Run: func(cmd *cobra.Command, args []string) {
 		client := github.NewClient()
-		err := client.DeleteRepo("FortinetCloudCSE", repoName)
+		err := client.DeleteRepo(orgName, repoName)
 		if err != nil {
 			fmt.Println("Error deleting repository:", err)
 			return
@@ -23,5 +23,6 @@ var deleteRepoCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(deleteRepoCmd)
 	deleteRepoCmd.Flags().StringVarP(&repoName, "name", "n", "", "Name of the repo")
+	addOrgFlag(deleteRepoCmd)
 	deleteRepoCmd.MarkFlagRequired("name")
 }
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const diffContext = 3
+
+type diffLine struct {
+	op   byte // ' ', '-' or '+'
+	text string
+}
+
+// diffLines computes a line diff of a against b from their longest common
+// subsequence.
+func diffLines(a, b []string) []diffLine {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{'+', b[j]})
+	}
+	return lines
+}
+
+// printDiff writes a unified-style diff of oldText and newText to w, showing
+// a few lines of context around each change. It returns false if the texts
+// are identical.
+func printDiff(w io.Writer, oldName, newName, oldText, newText string) bool {
+	lines := diffLines(strings.Split(oldText, "\n"), strings.Split(newText, "\n"))
+
+	// Mark the lines within diffContext of a change as visible.
+	visible := make([]bool, len(lines))
+	changed := false
+	for i, line := range lines {
+		if line.op == ' ' {
+			continue
+		}
+		changed = true
+		for k := max(0, i-diffContext); k <= min(len(lines)-1, i+diffContext); k++ {
+			visible[k] = true
+		}
+	}
+	if !changed {
+		return false
+	}
+
+	fmt.Fprintf(w, "--- %s\n+++ %s\n", oldName, newName)
+	for i, line := range lines {
+		if !visible[i] {
+			if i > 0 && visible[i-1] {
+				fmt.Fprintln(w, "...")
+			}
+			continue
+		}
+		fmt.Fprintf(w, "%c %s\n", line.op, line.text)
+	}
+	return true
+}
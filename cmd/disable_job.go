@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var disableJobCmd = &cobra.Command{
+	Use:   "disable-job",
+	Short: "Disable one or more Jenkins jobs, keeping their history",
+	Long: `Disable Jenkins jobs given by name or selected with --match. Disabled jobs keep
+their build history and can be re-enabled with enable-job.
+
+Example usage:
+  gh-jenkins-cli disable-job -n my-repo,other-repo
+  gh-jenkins-cli disable-job --match '/^workshops/2026/.*-lab$/'
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		toggleJobs(false)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(disableJobCmd)
+	addJobSelectionFlags(disableJobCmd)
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/robreris/gh-jenkins-cli/jenkins"
+	"github.com/spf13/cobra"
+)
+
+var jobNames []string
+
+var enableJobCmd = &cobra.Command{
+	Use:   "enable-job",
+	Short: "Enable one or more Jenkins jobs",
+	Long: `Enable Jenkins jobs given by name or selected with --match.
+
+Example usage:
+  gh-jenkins-cli enable-job -n my-repo
+  gh-jenkins-cli enable-job --folder workshops --match 'workshops/2026/*'
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		toggleJobs(true)
+	},
+}
+
+// toggleJobs enables or disables the jobs selected by the -n or --match flags
+// and reports the outcome for each, exiting non-zero if any failed.
+func toggleJobs(enable bool) {
+	action, done := "disable", "disabled"
+	if enable {
+		action, done = "enable", "enabled"
+	}
+
+	if len(jobNames) == 0 && matchPattern == "" {
+		log.Fatal("Specify jobs with -n or --match.")
+	}
+
+	client := jenkins.NewAPIClient()
+
+	var jobs []jenkins.JobPath
+	for _, name := range jobNames {
+		jobs = append(jobs, jenkins.ParseJobPath(name))
+	}
+
+	if matchPattern != "" {
+		match, err := newNameMatcher(matchPattern)
+		if err != nil {
+			log.Fatal("Error parsing --match: ", err)
+		}
+
+		allJobs, err := client.ListJobs(jenkins.ParseJobPath(listFolder))
+		if err != nil {
+			log.Fatal("Error listing Jenkins jobs: ", err)
+		}
+		// Branch jobs are enabled and disabled through their multibranch
+		// project, so a match selects the project once.
+		seen := make(map[string]bool)
+		for _, job := range allJobs {
+			if !match(job.FullName) {
+				continue
+			}
+			name := job.FullName
+			if job.Project != "" {
+				name = job.Project
+			}
+			if !seen[name] {
+				seen[name] = true
+				jobs = append(jobs, jenkins.ParseJobPath(name))
+			}
+		}
+	}
+
+	if len(jobs) == 0 {
+		fmt.Println("No matching jobs found.")
+		return
+	}
+
+	failed := 0
+	for _, job := range jobs {
+		var err error
+		if enable {
+			err = client.EnableJob(job)
+		} else {
+			err = client.DisableJob(job)
+		}
+
+		if err != nil {
+			fmt.Printf("Failed to %s Jenkins job '%s': %v\n", action, job, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Jenkins job '%s' %s.\n", job, done)
+	}
+
+	fmt.Printf("%d of %d jobs %s.\n", len(jobs)-failed, len(jobs), done)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func addJobSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVarP(&jobNames, "name", "n", nil, "Names of Jenkins jobs, including any folders. Separate multiple jobs with commas.")
+	cmd.Flags().StringVarP(&matchPattern, "match", "m", "", "Select all jobs whose full path matches this glob, or /regex/.")
+	cmd.Flags().StringVarP(&listFolder, "folder", "f", "", "Only match jobs inside this folder (e.g. workshops/2026).")
+}
+
+func init() {
+	rootCmd.AddCommand(enableJobCmd)
+	addJobSelectionFlags(enableJobCmd)
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/robreris/gh-jenkins-cli/github"
+	"github.com/spf13/cobra"
+)
+
+var resendExpired bool
+
+var invitationsCmd = &cobra.Command{
+	Use:   "invitations",
+	Short: "Manage pending invitations to collaborate on repos",
+	Long: `Manage the invitations GitHub sends when a user outside the org is added as a
+collaborator. Invitations expire after 7 days if not accepted. Commands work on
+one repo with --repo-name or on every repo in the org with --all-repos.`,
+}
+
+var invitationsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List pending invitations",
+	Long: `List pending invitations.
+
+Example usage:
+  gh-jenkins-cli invitations list -r my-repo
+  gh-jenkins-cli invitations list --all-repos
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := github.NewClient()
+		invitations, failed := selectedInvitations(client)
+
+		if len(invitations) == 0 {
+			fmt.Println("No pending invitations.")
+			if failed > 0 {
+				os.Exit(1)
+			}
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "REPO\tLOGIN\tPERMISSION\tINVITED BY\tINVITED\tSTATUS")
+		for _, inv := range invitations {
+			status := "pending"
+			if inv.Expired {
+				status = "expired"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", inv.Repo, inv.Login, inv.Permission, inv.Inviter,
+				inv.CreatedAt.Local().Format(time.DateTime), status)
+		}
+		w.Flush()
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+var invitationsResendCmd = &cobra.Command{
+	Use:   "resend",
+	Short: "Replace invitations with new ones",
+	Long: `Delete invitations and invite the same users again with the same permission,
+which sends a new email and restarts the 7 day expiry. Select the invitations
+with --collaborators, --expired or both.
+
+Example usage:
+  gh-jenkins-cli invitations resend -r my-repo -c user1,user2
+  gh-jenkins-cli invitations resend --all-repos --expired
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if collaborators == "" && !resendExpired {
+			log.Fatal("Give --collaborators, --expired or both.")
+		}
+
+		var logins []string
+		if collaborators != "" {
+			logins = strings.Split(collaborators, ",")
+		}
+
+		client := github.NewClient()
+		invitations, failed := selectedInvitations(client)
+		for _, inv := range invitations {
+			if !(resendExpired && inv.Expired) && !containsFold(logins, inv.Login) {
+				continue
+			}
+			if err := client.ResendInvitation(orgName, inv); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				failed++
+				continue
+			}
+			fmt.Printf("Invitation of %s to repository '%s' resent.\n", inv.Login, inv.Repo)
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+var invitationsCancelExpiredCmd = &cobra.Command{
+	Use:   "cancel-expired",
+	Short: "Delete expired invitations",
+	Long: `Delete invitations that expired without being accepted.
+
+Example usage:
+  gh-jenkins-cli invitations cancel-expired --all-repos --dry-run
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := github.NewClient()
+		invitations, failed := selectedInvitations(client)
+		cancelled := 0
+		for _, inv := range invitations {
+			if !inv.Expired {
+				continue
+			}
+			fmt.Printf("%s: invitation of %s from %s\n", inv.Repo, inv.Login, inv.CreatedAt.Local().Format(time.DateOnly))
+			if dryRun {
+				cancelled++
+				continue
+			}
+			if err := client.CancelInvitation(orgName, inv); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				failed++
+				continue
+			}
+			cancelled++
+		}
+
+		if dryRun {
+			fmt.Printf("%d expired invitations would be cancelled, %d failed.\n", cancelled, failed)
+		} else {
+			fmt.Printf("Cancelled %d expired invitations, %d failed.\n", cancelled, failed)
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// selectedInvitations returns the pending invitations of the repos chosen by
+// --repo-name or --all-repos, and the number of repos whose invitations could
+// not be listed. Their errors are printed so the other repos can still be
+// handled.
+func selectedInvitations(client *github.Client) ([]*github.Invitation, int) {
+	var all []*github.Invitation
+	failed := 0
+	for _, repo := range selectedRepos(client) {
+		invitations, err := client.ListInvitations(orgName, repo)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed++
+			continue
+		}
+		all = append(all, invitations...)
+	}
+	return all, failed
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	rootCmd.AddCommand(invitationsCmd)
+	invitationsCmd.AddCommand(invitationsListCmd, invitationsResendCmd, invitationsCancelExpiredCmd)
+
+	for _, cmd := range invitationsCmd.Commands() {
+		cmd.Flags().StringVarP(&repoName, "repo-name", "r", "", "GitHub repository name")
+		cmd.Flags().BoolVar(&allRepos, "all-repos", false, "Work on every repo in the org.")
+		addOrgFlag(cmd)
+	}
+
+	invitationsResendCmd.Flags().StringVarP(&collaborators, "collaborators", "c", "", "Comma-separated logins whose invitations to resend")
+	invitationsResendCmd.Flags().BoolVar(&resendExpired, "expired", false, "Resend every expired invitation.")
+	invitationsCancelExpiredCmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the expired invitations without cancelling them.")
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/robreris/gh-jenkins-cli/jenkins"
+	"github.com/spf13/cobra"
+)
+
+var (
+	buildCount  int
+	watchStatus bool
+)
+
+const watchInterval = 5 * time.Second
+
+var jobStatusCmd = &cobra.Command{
+	Use:   "job-status",
+	Short: "Show the recent build history of a Jenkins job",
+	Long: `Show the last builds of a Jenkins job with their result, duration, start time,
+triggering cause and the commit they built.
+
+Example usage:
+  gh-jenkins-cli job-status -n my-repo
+  gh-jenkins-cli job-status -n workshops/2026/my-repo -c 10 --watch
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := jenkins.NewAPIClient()
+		job := jenkins.ParseJobPath(jobName)
+
+		for {
+			status, err := client.GetJob(job, buildCount)
+			if err != nil {
+				log.Fatal("Error fetching Jenkins job status: ", err)
+			}
+
+			printJobStatus(status)
+
+			if !watchStatus || !status.IsBuilding() {
+				return
+			}
+			time.Sleep(watchInterval)
+			fmt.Println()
+		}
+	},
+}
+
+func printJobStatus(job *jenkins.Job) {
+	fmt.Printf("Job '%s' (%s) as of %s\n", job.FullName, job.URL, time.Now().Format(time.TimeOnly))
+
+	if len(job.Builds) == 0 {
+		fmt.Println("No builds yet.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BUILD\tRESULT\tDURATION\tSTARTED\tCAUSE\tCOMMIT")
+	for _, build := range job.Builds {
+		result := build.Result
+		if build.Building {
+			result = "BUILDING"
+		}
+
+		commit := build.CommitSHA()
+		if len(commit) > 10 {
+			commit = commit[:10]
+		}
+
+		fmt.Fprintf(w, "#%d\t%s\t%s\t%s\t%s\t%s\n",
+			build.Number, result, build.Elapsed(), build.StartTime().Format(time.DateTime), build.Cause(), commit)
+	}
+	w.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(jobStatusCmd)
+	jobStatusCmd.Flags().StringVarP(&jobName, "name", "n", "", "Name of Jenkins job, including any folders (e.g. workshops/2026/my-repo).")
+	jobStatusCmd.Flags().IntVarP(&buildCount, "count", "c", 5, "Number of recent builds to show.")
+	jobStatusCmd.Flags().BoolVarP(&watchStatus, "watch", "w", false, "Keep refreshing while a build is running.")
+	jobStatusCmd.MarkFlagRequired("name")
+}
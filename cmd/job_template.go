@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"github.com/robreris/gh-jenkins-cli/jenkins"
+	"github.com/spf13/cobra"
+)
+
+// Flags shared by the commands that render the job config XML template.
+var (
+	jobTemplate jenkins.JobTemplateData
+	jobTypeName string
+)
+
+func addJobTemplateFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&jobTypeName, "type", string(jenkins.Pipeline), "Job type: pipeline, or multibranch for one job per branch and pull request.")
+	cmd.Flags().StringVar(&jobTemplate.CredentialsID, "credentials-id", "jenkins-git", "Jenkins credentials ID used to check out the repo.")
+	cmd.Flags().StringVar(&jobTemplate.BranchSpec, "branch-spec", "*/**", "Branches the job builds.")
+	cmd.Flags().StringVar(&jobTemplate.ScriptPath, "script-path", "Jenkinsfile", "Path to the Jenkinsfile within the repo.")
+	cmd.Flags().StringVar(&jobTemplate.Description, "description", "", "Description of the Jenkins job.")
+	cmd.Flags().StringToStringVar(&jobTemplate.Vars, "var", nil, "Extra template value as key=value, available as {{.Vars.key}}. Repeat for multiple values.")
+	cmd.Flags().IntVar(&jobTemplate.OrphanDaysToKeep, "orphan-days", 14, "Multibranch only: days to keep jobs for deleted branches and closed PRs, -1 for no limit.")
+	cmd.Flags().IntVar(&jobTemplate.OrphanNumToKeep, "orphan-count", -1, "Multibranch only: number of jobs for deleted branches and closed PRs to keep, -1 for no limit.")
+}
+
+// renderJobConfig renders the config XML template for the job type selected
+// with --type.
+func renderJobConfig(templatePath string, data jenkins.JobTemplateData) ([]byte, error) {
+	jobType, err := jenkins.ParseJobType(jobTypeName)
+	if err != nil {
+		return nil, err
+	}
+	return jenkins.RenderJobConfig(templatePath, jobType, data)
+}
+
+// addJobRepoFlags registers the flags naming the GitHub repo a job builds, for
+// commands where the job is not tied to a repo they create.
+func addJobRepoFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&jobTemplate.Org, "github-org", defaultOrg(), "GitHub organization of the repo the job builds. GITHUB_ORG sets the default.")
+	cmd.Flags().StringVar(&jobTemplate.Repo, "github-repo", "", "GitHub repository the job builds. Defaults to the job name.")
+}
+
+// jobTemplateData returns the template data for a job from the template
+// flags, defaulting the repo to the job's name.
+func jobTemplateData(job jenkins.JobPath) jenkins.JobTemplateData {
+	data := jobTemplate
+	data.JobName = job.Name()
+	if data.Repo == "" {
+		data.Repo = job.Name()
+	}
+	return data
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/robreris/gh-jenkins-cli/github"
+	"github.com/robreris/gh-jenkins-cli/jenkins"
+	"github.com/spf13/cobra"
+)
+
+var lintFile string
+
+var lintJenkinsfileCmd = &cobra.Command{
+	Use:   "lint-jenkinsfile",
+	Short: "Validate a Jenkinsfile against Jenkins",
+	Long: `Validate a declarative Jenkinsfile with Jenkins' pipeline linter and print any
+errors with their line and column. Exits non-zero if the Jenkinsfile is invalid.
+
+Example usage:
+  gh-jenkins-cli lint-jenkinsfile
+  gh-jenkins-cli lint-jenkinsfile -f path/to/Jenkinsfile
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		content, err := github.LoadJenkinsfile(lintFile)
+		if err != nil {
+			log.Fatal("Error loading Jenkinsfile: ", err)
+		}
+
+		client := jenkins.NewAPIClient()
+		if err := validateJenkinsfile(client, content); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println("Jenkinsfile is valid.")
+	},
+}
+
+// validateJenkinsfile returns an error listing every problem Jenkins found in
+// the Jenkinsfile.
+func validateJenkinsfile(client *jenkins.APIClient, content string) error {
+	errs, err := client.ValidateJenkinsfile(content)
+	if err != nil {
+		return fmt.Errorf("error validating Jenkinsfile: %v", err)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+
+	msg := "Jenkinsfile is invalid:"
+	for _, e := range errs {
+		msg += "\n  " + e.String()
+	}
+	return errors.New(msg)
+}
+
+func init() {
+	rootCmd.AddCommand(lintJenkinsfileCmd)
+	lintJenkinsfileCmd.Flags().StringVarP(&lintFile, "file", "f", "", "Path to the Jenkinsfile to validate. Defaults to the built-in Jenkinsfile.")
+}
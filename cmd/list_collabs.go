@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/robreris/gh-jenkins-cli/github"
+	"github.com/spf13/cobra"
+)
+
+var listCollabsCmd = &cobra.Command{
+	Use:   "list-collabs",
+	Short: "List the collaborators of a GitHub repository",
+	Long: `List the users given access to a repository directly, with their permission and
+whether they have yet to accept their invitation. Access through org
+membership or teams is not listed; see list-teams.
+
+Example usage:
+  gh-jenkins-cli list-collabs -r my-repo
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := github.NewClient()
+
+		collabs, err := client.ListCollaborators(orgName, repoName)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "LOGIN\tPERMISSION\tSTATUS")
+		for _, c := range collabs {
+			status := "active"
+			if c.Expired {
+				status = "invitation expired"
+			} else if c.Pending {
+				status = "invitation pending"
+			}
+			permission := c.Permission
+			if c.Role != "" {
+				permission = fmt.Sprintf("%s (role %s)", c.Permission, c.Role)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", c.Login, permission, status)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(listCollabsCmd)
+	listCollabsCmd.Flags().StringVarP(&repoName, "repo-name", "r", "", "GitHub repository name (required)")
+	addOrgFlag(listCollabsCmd)
+	listCollabsCmd.MarkFlagRequired("repo-name")
+}
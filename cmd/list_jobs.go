@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/robreris/gh-jenkins-cli/jenkins"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listFolder   string
+	matchPattern string
+	statusFilter string
+	outputFormat string
+)
+
+// Job statuses selected by each --status value.
+var statusFilters = map[string][]string{
+	"failing":  {"failing", "unstable"},
+	"passing":  {"passing"},
+	"disabled": {"disabled"},
+}
+
+type jobListing struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Status string `json:"status"`
+}
+
+var listJobsCmd = &cobra.Command{
+	Use:   "list-jobs",
+	Short: "List Jenkins jobs, including jobs inside folders",
+	Long: `List Jenkins jobs, descending into folders, organization folders and
+multibranch projects, whose branch jobs are listed by their full path.
+
+--match takes a glob (workshops/*/ws-*) matched against the full job path, or a
+regular expression wrapped in slashes (/ws-.*-2026/). --status failing also
+includes unstable jobs.
+
+Example usage:
+  gh-jenkins-cli list-jobs
+  gh-jenkins-cli list-jobs --folder workshops --match 'workshops/2026/*' --status failing -o json
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		match, err := newNameMatcher(matchPattern)
+		if err != nil {
+			log.Fatal("Error parsing --match: ", err)
+		}
+
+		var statuses []string
+		if statusFilter != "" {
+			var ok bool
+			if statuses, ok = statusFilters[statusFilter]; !ok {
+				log.Fatalf("Invalid --status %q: use failing, passing or disabled", statusFilter)
+			}
+		}
+
+		client := jenkins.NewAPIClient()
+		jobs, err := client.ListJobs(jenkins.ParseJobPath(listFolder))
+		if err != nil {
+			log.Fatal("Error listing Jenkins jobs: ", err)
+		}
+
+		listings := []jobListing{}
+		for _, job := range jobs {
+			if !match(job.FullName) || (statuses != nil && !slices.Contains(statuses, job.Status())) {
+				continue
+			}
+			listings = append(listings, jobListing{Name: job.FullName, URL: job.URL, Status: job.Status()})
+		}
+
+		switch outputFormat {
+		case "json":
+			out, err := json.MarshalIndent(listings, "", "  ")
+			if err != nil {
+				log.Fatal("Error encoding jobs: ", err)
+			}
+			fmt.Println(string(out))
+		case "table":
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "JOB\tSTATUS\tURL")
+			for _, l := range listings {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", l.Name, l.Status, l.URL)
+			}
+			w.Flush()
+		default:
+			log.Fatalf("Invalid --output %q: use table or json", outputFormat)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(listJobsCmd)
+	listJobsCmd.Flags().StringVarP(&listFolder, "folder", "f", "", "Only list jobs inside this folder (e.g. workshops/2026).")
+	listJobsCmd.Flags().StringVarP(&matchPattern, "match", "m", "", "Glob, or /regex/, the full job path must match.")
+	listJobsCmd.Flags().StringVarP(&statusFilter, "status", "s", "", "Only list jobs with this status: failing, passing or disabled.")
+	listJobsCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table or json.")
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/robreris/gh-jenkins-cli/github"
+	"github.com/spf13/cobra"
+)
+
+var listStagesCmd = &cobra.Command{
+	Use:   "list-stages",
+	Short: "List the stages of the Jenkinsfile template and whether they run by default",
+	Long: `List the stages of the Jenkinsfile template that create-project commits, with
+their default state. Use the names with create-project --enable-stage and
+--disable-stage.
+
+Example usage:
+  gh-jenkins-cli list-stages
+  gh-jenkins-cli list-stages --jenkinsfile my-templates/Jenkinsfile
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		content, err := github.LoadJenkinsfile(jenkinsfile)
+		if err != nil {
+			log.Fatal("Error loading Jenkinsfile: ", err)
+		}
+
+		stages, err := github.ParseStages(content)
+		if err != nil {
+			log.Fatal("Error parsing Jenkinsfile: ", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "STAGE\tDEFAULT")
+		for _, stage := range stages {
+			state := "enabled"
+			if !stage.Enabled {
+				state = "disabled"
+			}
+			fmt.Fprintf(w, "%s\t%s\n", stage.Name, state)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(listStagesCmd)
+	listStagesCmd.Flags().StringVar(&jenkinsfile, "jenkinsfile", "", "Path to the Jenkinsfile template. Defaults to the built-in Jenkinsfile.")
+}
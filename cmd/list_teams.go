@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/robreris/gh-jenkins-cli/github"
+	"github.com/spf13/cobra"
+)
+
+var listTeamsCmd = &cobra.Command{
+	Use:   "list-teams",
+	Short: "List the teams in the GitHub org, or the teams with access to a repo",
+	Long: `List the teams in the GitHub org. With --repo-name, list the teams with access
+to that repo and their permission.
+
+Example usage:
+  gh-jenkins-cli list-teams
+  gh-jenkins-cli list-teams -r my-repo
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := github.NewClient()
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		if repoName == "" {
+			teams, err := client.ListTeams(orgName)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Fprintln(w, "SLUG\tNAME\tDESCRIPTION")
+			for _, t := range teams {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", t.Slug, t.Name, t.Description)
+			}
+		} else {
+			teams, err := client.ListRepoTeams(orgName, repoName)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Fprintln(w, "SLUG\tNAME\tPERMISSION")
+			for _, t := range teams {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", t.Slug, t.Name, t.Permission)
+			}
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(listTeamsCmd)
+	listTeamsCmd.Flags().StringVarP(&repoName, "repo-name", "r", "", "List the teams with access to this repository")
+	addOrgFlag(listTeamsCmd)
+}
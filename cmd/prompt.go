@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// confirm asks a yes/no question on stdin and reports whether the user
+// answered yes. Anything other than y/yes counts as no.
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// readSecret returns value, or a line read from stdin if value is "-", so
+// secrets can be piped in instead of appearing in the process list.
+func readSecret(value string) (string, error) {
+	if value != "-" {
+		return value, nil
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read secret from stdin: %v", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/robreris/gh-jenkins-cli/github"
+	"github.com/spf13/cobra"
+)
+
+// Protection profile selection and per-field overrides.
+var (
+	protectionName      string
+	protectionConfig    string
+	protectionMode      string
+	protectTags         bool
+	orgRuleset          bool
+	branchName          string
+	requiredChecks      []string
+	strictChecks        bool
+	requiredReviews     int
+	requireCodeOwners   bool
+	dismissStaleReviews bool
+	enforceAdmins       bool
+	linearHistory       bool
+	pushUsers           []string
+	pushTeams           []string
+)
+
+var protectBranchCmd = &cobra.Command{
+	Use:   "protect-branch",
+	Short: "Apply a branch protection profile to a repo branch",
+	Long: `Apply a named branch protection profile to a branch. Profiles come from the
+built-in set ("default" and "customer-facing") and an optional JSON file given
+with --protection-config, for example:
+
+  {
+    "customer-facing": {
+      "required_checks": ["ci/jenkins/build-status"],
+      "strict_checks": true,
+      "pull_requests_optional": false,
+      "required_reviews": 1,
+      "require_code_owners": true,
+      "dismiss_stale_reviews": true,
+      "enforce_admins": false,
+      "linear_history": true,
+      "allow_force_pushes": false,
+      "allow_deletions": false,
+      "push_users": [],
+      "push_teams": ["workshop-admins"]
+    }
+  }
+
+The other flags override individual fields of the selected profile.
+
+With --protection-mode ruleset the profile is applied as a repository ruleset
+instead of classic branch protection, and --protect-tags adds a ruleset that
+stops tags from being moved or deleted. --org-ruleset applies the profile as
+an org ruleset targeting every repo generated from the template repo so far.
+The ruleset lists the repos by name: create-repo and create-project add the
+repos they generate, but repos generated some other way are only covered once
+--org-ruleset is run again.
+
+Example usage:
+  gh-jenkins-cli protect-branch -r my-repo --protection customer-facing
+  gh-jenkins-cli protect-branch -r my-repo --protection-config profiles.json --protection strict --reviews 2
+  gh-jenkins-cli protect-branch -r my-repo --protection-mode ruleset --protect-tags
+  gh-jenkins-cli protect-branch --org-ruleset --template-repo UserRepo
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := protectionProfile(cmd)
+		if err != nil {
+			log.Fatal("Error selecting protection profile: ", err)
+		}
+		useRulesets, err := useRulesets()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		client := github.NewClient()
+		rulesetOpts := github.RulesetOptions{Branch: branchName, Profile: profile, ProtectTags: protectTags}
+
+		if orgRuleset {
+			owner := sourceTemplateOwner()
+			repos, err := client.ReposFromTemplate(orgName, owner, templateRepo)
+			if err != nil {
+				log.Fatal("Error finding repositories generated from the template: ", err)
+			}
+
+			if err := client.ApplyOrgRuleset(orgName, orgRulesetName(), repos, rulesetOpts); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		if repoName == "" {
+			log.Fatal("--repo-name is required unless --org-ruleset is given.")
+		}
+
+		if useRulesets {
+			err = client.ApplyRepoRuleset(orgName, repoName, rulesetOpts)
+		} else {
+			err = client.AddBranchProtection(orgName, repoName, branchName, profile)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Branch '%s' in repository '%s' protected with profile '%s'.\n", branchName, repoName, protectionName)
+	},
+}
+
+// orgRulesetName names the org rulesets --org-ruleset applies for the
+// template repo. create-repo and create-project find them by this name.
+func orgRulesetName() string {
+	return github.TemplateRulesetName(sourceTemplateOwner(), templateRepo)
+}
+
+// addProtectionFlags registers the flags selecting and overriding a branch
+// protection profile.
+func addProtectionFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&protectionName, "protection", github.DefaultProtectionProfile, "Branch protection profile to apply.")
+	cmd.Flags().StringVar(&protectionConfig, "protection-config", "", "JSON file with additional branch protection profiles.")
+	cmd.Flags().StringVar(&protectionMode, "protection-mode", "classic", "How to apply the profile: classic branch protection or ruleset.")
+	cmd.Flags().BoolVar(&protectTags, "protect-tags", false, "With --protection-mode ruleset, also stop tags from being moved or deleted.")
+	cmd.Flags().StringSliceVar(&requiredChecks, "required-checks", nil, "Override: status checks that must pass, separated by commas.")
+	cmd.Flags().BoolVar(&strictChecks, "strict-checks", false, "Override: require branches to be up to date before merging.")
+	cmd.Flags().IntVar(&requiredReviews, "reviews", 0, "Override: number of approving reviews required.")
+	cmd.Flags().BoolVar(&requireCodeOwners, "code-owners", false, "Override: require code owner reviews.")
+	cmd.Flags().BoolVar(&dismissStaleReviews, "dismiss-stale", false, "Override: dismiss approvals when new commits are pushed.")
+	cmd.Flags().BoolVar(&enforceAdmins, "enforce-admins", false, "Override: apply the rules to admins too.")
+	cmd.Flags().BoolVar(&linearHistory, "linear-history", false, "Override: require a linear history.")
+	cmd.Flags().StringSliceVar(&pushUsers, "push-users", nil, "Override: only these users (and --push-teams) may push, separated by commas.")
+	cmd.Flags().StringSliceVar(&pushTeams, "push-teams", nil, "Override: only these team slugs (and --push-users) may push, separated by commas.")
+}
+
+// useRulesets reports whether --protection-mode selects rulesets.
+func useRulesets() (bool, error) {
+	switch protectionMode {
+	case "classic":
+		return false, nil
+	case "ruleset":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid --protection-mode %q: use classic or ruleset", protectionMode)
+	}
+}
+
+// protectionProfile returns the profile selected with --protection, with any
+// override flags given on the command line applied.
+func protectionProfile(cmd *cobra.Command) (github.ProtectionProfile, error) {
+	profiles, err := github.LoadProtectionProfiles(protectionConfig)
+	if err != nil {
+		return github.ProtectionProfile{}, err
+	}
+
+	profile, err := github.LookupProtectionProfile(profiles, protectionName)
+	if err != nil {
+		return github.ProtectionProfile{}, err
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("required-checks") {
+		profile.RequiredChecks = requiredChecks
+	}
+	if flags.Changed("strict-checks") {
+		profile.StrictChecks = strictChecks
+	}
+	if flags.Changed("reviews") {
+		profile.RequiredReviews = requiredReviews
+	}
+	if flags.Changed("code-owners") {
+		profile.RequireCodeOwners = requireCodeOwners
+	}
+	if flags.Changed("dismiss-stale") {
+		profile.DismissStaleReviews = dismissStaleReviews
+	}
+	if flags.Changed("enforce-admins") {
+		profile.EnforceAdmins = enforceAdmins
+	}
+	if flags.Changed("linear-history") {
+		profile.LinearHistory = linearHistory
+	}
+	if flags.Changed("push-users") {
+		profile.PushUsers = pushUsers
+	}
+	if flags.Changed("push-teams") {
+		profile.PushTeams = pushTeams
+	}
+	return profile, nil
+}
+
+func init() {
+	rootCmd.AddCommand(protectBranchCmd)
+	protectBranchCmd.Flags().StringVarP(&repoName, "repo-name", "r", "", "GitHub repository name (required unless --org-ruleset)")
+	protectBranchCmd.Flags().StringVarP(&branchName, "branch", "b", "main", "Branch to protect.")
+	protectBranchCmd.Flags().BoolVar(&orgRuleset, "org-ruleset", false, "Apply the profile as an org ruleset targeting all repos generated from the template repo.")
+	addOrgFlag(protectBranchCmd)
+	addTemplateRepoFlags(protectBranchCmd)
+	addProtectionFlags(protectBranchCmd)
+}
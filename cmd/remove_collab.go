@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/robreris/gh-jenkins-cli/github"
+	"github.com/spf13/cobra"
+)
+
+var removeCollabCmd = &cobra.Command{
+	Use:   "remove-collab",
+	Short: "Remove collaborators from a GitHub repository",
+	Long: `Remove one or more collaborators from a repository. Pending invitations are
+cancelled.
+
+Example usage:
+  gh-jenkins-cli remove-collab -r my-repo -c user1,user2
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := github.NewClient()
+
+		collabs, err := client.ListCollaborators(orgName, repoName)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, login := range strings.Split(collaborators, ",") {
+			collab := findCollaborator(collabs, login)
+			if collab == nil {
+				log.Fatalf("'%s' is not a collaborator of repository '%s'.", login, repoName)
+			}
+			if err := client.RemoveCollaborator(orgName, repoName, collab); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("Collaborator %s removed from repository '%s'.\n", collab.Login, repoName)
+		}
+	},
+}
+
+func findCollaborator(collabs []*github.Collaborator, login string) *github.Collaborator {
+	for _, c := range collabs {
+		if strings.EqualFold(c.Login, login) {
+			return c
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(removeCollabCmd)
+	removeCollabCmd.Flags().StringVarP(&repoName, "repo-name", "r", "", "GitHub repository name (required)")
+	removeCollabCmd.Flags().StringVarP(&collaborators, "collaborators", "c", "", "Comma-separated list of collaborators to remove (required)")
+	addOrgFlag(removeCollabCmd)
+	removeCollabCmd.MarkFlagRequired("repo-name")
+	removeCollabCmd.MarkFlagRequired("collaborators")
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/robreris/gh-jenkins-cli/github"
+	"github.com/spf13/cobra"
+)
+
+var teamSlugs []string
+
+var removeTeamCmd = &cobra.Command{
+	Use:   "remove-team",
+	Short: "Remove teams' access to a GitHub repository",
+	Long: `Remove the access of one or more org teams to a repository. Members keep any
+access they have individually or through other teams.
+
+Example usage:
+  gh-jenkins-cli remove-team -r my-repo -t team-a,team-b
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := github.NewClient()
+
+		if err := client.ValidateTeams(orgName, teamSlugs); err != nil {
+			log.Fatal(err)
+		}
+		for _, slug := range teamSlugs {
+			if err := client.RemoveTeam(orgName, repoName, slug); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("Team '%s' removed from repository '%s'.\n", slug, repoName)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(removeTeamCmd)
+	removeTeamCmd.Flags().StringVarP(&repoName, "repo-name", "r", "", "GitHub repository name (required)")
+	removeTeamCmd.Flags().StringSliceVarP(&teamSlugs, "teams", "t", nil, "Comma-separated team slugs to remove (required)")
+	addOrgFlag(removeTeamCmd)
+	removeTeamCmd.MarkFlagRequired("repo-name")
+	removeTeamCmd.MarkFlagRequired("teams")
+}
@@ -10,7 +10,45 @@ import (
 var rootCmd = &cobra.Command{
 	Use:   "gh-jenkins-cli",
 	Short: "A CLI tool for working with GitHub and Jenkins.",
-	Long:  "A CLI tool to work cross-platform for use building and working with FortinetCloudCSE repos and Jenkins pipelines.",
+	Long:  "A CLI tool to work cross-platform for use building and working with FortinetCloudCSE repos and Jenkins pipelines. Set GITHUB_ORG to work with another GitHub org by default.",
+}
+
+// GitHub flags shared by the commands that work with repos.
+var (
+	orgName       string
+	templateOwner string
+	templateRepo  string
+)
+
+// envOr returns the value of an environment variable, or fallback if unset.
+func envOr(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// defaultOrg is the org used when --org is not given, configurable with
+// GITHUB_ORG.
+func defaultOrg() string {
+	return envOr("GITHUB_ORG", "FortinetCloudCSE")
+}
+
+func addOrgFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&orgName, "org", "o", defaultOrg(), "GitHub organization. GITHUB_ORG sets the default.")
+}
+
+func addTemplateRepoFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&templateOwner, "template-owner", os.Getenv("GITHUB_TEMPLATE_OWNER"), "Owner of the template repo. GITHUB_TEMPLATE_OWNER sets the default, otherwise --org is used.")
+	cmd.Flags().StringVar(&templateRepo, "template-repo", envOr("GITHUB_TEMPLATE_REPO", "UserRepo"), "Template repo new repos are generated from. GITHUB_TEMPLATE_REPO sets the default.")
+}
+
+// sourceTemplateOwner returns --template-owner, defaulting to --org.
+func sourceTemplateOwner() string {
+	if templateOwner == "" {
+		return orgName
+	}
+	return templateOwner
 }
 
 func Execute() {
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// stepState is what a step's check found before running it.
+type stepState int
+
+const (
+	stateMissing stepState = iota // nothing in place yet; run the step
+	stateDiffers                  // in place but out of date; converge it
+	stateInPlace                  // already done, e.g. by an earlier run; skip it
+)
+
+// step is one action of a multi-step command.
+//
+// check inspects existing state so that re-running a command picks up where
+// an earlier run stopped; a nil check always runs the step. converge brings
+// existing state up to date and defaults to run. undo reverses run and is nil
+// for steps that need no reversing, e.g. because undoing an earlier step
+// (deleting the repo) also removes what they did. Skipped and converged steps
+// are never undone, since they changed nothing this run created.
+type step struct {
+	name     string
+	check    func() (stepState, error)
+	run      func() error
+	converge func() error
+	undo     func() error
+}
+
+type stepStatus string
+
+const (
+	stepNotRun     stepStatus = "not run"
+	stepDone       stepStatus = "done"
+	stepSkipped    stepStatus = "skipped (already done)"
+	stepConverged  stepStatus = "updated"
+	stepFailed     stepStatus = "failed"
+	stepUndone     stepStatus = "undone"
+	stepUndoFailed stepStatus = "undo failed"
+)
+
+type stepResult struct {
+	status stepStatus
+	err    error
+}
+
+// execute checks the step's state and runs, converges or skips it.
+func (s step) execute() (stepStatus, error) {
+	state := stateMissing
+	if s.check != nil {
+		var err error
+		if state, err = s.check(); err != nil {
+			return stepFailed, err
+		}
+	}
+
+	switch state {
+	case stateInPlace:
+		return stepSkipped, nil
+	case stateDiffers:
+		converge := s.converge
+		if converge == nil {
+			converge = s.run
+		}
+		if err := converge(); err != nil {
+			return stepFailed, err
+		}
+		return stepConverged, nil
+	default:
+		if err := s.run(); err != nil {
+			return stepFailed, err
+		}
+		return stepDone, nil
+	}
+}
+
+// runSteps runs the steps in order and stops at the first failure. If
+// rollback is set, the steps that ran are then undone in reverse order. A
+// report of each step's outcome is printed either way, and the error of the
+// failed step returned.
+func runSteps(steps []step, rollback bool) error {
+	results := make([]stepResult, len(steps))
+	for i := range results {
+		results[i].status = stepNotRun
+	}
+
+	var failure error
+	failed := -1
+	for i, s := range steps {
+		fmt.Printf("==> %s\n", s.name)
+		status, err := s.execute()
+		results[i] = stepResult{status, err}
+		if err != nil {
+			failure = fmt.Errorf("%s: %v", s.name, err)
+			failed = i
+			break
+		}
+	}
+
+	if failure != nil && rollback {
+		for i := failed - 1; i >= 0; i-- {
+			if steps[i].undo == nil || results[i].status != stepDone {
+				continue
+			}
+			fmt.Printf("<== Undoing: %s\n", steps[i].name)
+			if err := steps[i].undo(); err != nil {
+				results[i] = stepResult{stepUndoFailed, err}
+				continue
+			}
+			results[i].status = stepUndone
+		}
+	}
+
+	printStepReport(steps, results)
+	return failure
+}
+
+func printStepReport(steps []step, results []stepResult) {
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STEP\tSTATUS\tERROR")
+	for i, s := range steps {
+		errText := ""
+		if results[i].err != nil {
+			errText = results[i].err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", s.name, results[i].status, errText)
+	}
+	w.Flush()
+}
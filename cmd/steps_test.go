@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// fakeStep describes a step for TestRunSteps: the state its check reports,
+// whether running it fails, and whether undoing it fails.
+type fakeStep struct {
+	name     string
+	state    stepState
+	fail     bool
+	failUndo bool
+	noUndo   bool
+}
+
+func TestRunSteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		steps    []fakeStep
+		rollback bool
+		wantLog  []string
+		wantErr  string
+	}{
+		{
+			name:     "all succeed",
+			steps:    []fakeStep{{name: "a"}, {name: "b"}},
+			rollback: true,
+			wantLog:  []string{"run a", "run b"},
+		},
+		{
+			name:     "failure undoes in reverse order",
+			steps:    []fakeStep{{name: "a"}, {name: "b"}, {name: "c", fail: true}, {name: "d"}},
+			rollback: true,
+			wantLog:  []string{"run a", "run b", "run c", "undo b", "undo a"},
+			wantErr:  "c: c failed",
+		},
+		{
+			name:     "no rollback",
+			steps:    []fakeStep{{name: "a"}, {name: "b", fail: true}},
+			rollback: false,
+			wantLog:  []string{"run a", "run b"},
+			wantErr:  "b: b failed",
+		},
+		{
+			name: "skipped and converged steps are not undone",
+			steps: []fakeStep{
+				{name: "a"},
+				{name: "b", state: stateInPlace},
+				{name: "c", state: stateDiffers},
+				{name: "d", fail: true},
+			},
+			rollback: true,
+			wantLog:  []string{"run a", "converge c", "run d", "undo a"},
+			wantErr:  "d: d failed",
+		},
+		{
+			name:     "steps without undo are passed over",
+			steps:    []fakeStep{{name: "a"}, {name: "b", noUndo: true}, {name: "c", fail: true}},
+			rollback: true,
+			wantLog:  []string{"run a", "run b", "run c", "undo a"},
+			wantErr:  "c: c failed",
+		},
+		{
+			name:     "undo failure does not stop the rollback",
+			steps:    []fakeStep{{name: "a"}, {name: "b", failUndo: true}, {name: "c", fail: true}},
+			rollback: true,
+			wantLog:  []string{"run a", "run b", "run c", "undo b", "undo a"},
+			wantErr:  "c: c failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var log []string
+			var steps []step
+			for _, f := range tt.steps {
+				s := step{
+					name:  f.name,
+					check: func() (stepState, error) { return f.state, nil },
+					run: func() error {
+						log = append(log, "run "+f.name)
+						if f.fail {
+							return errors.New(f.name + " failed")
+						}
+						return nil
+					},
+					converge: func() error {
+						log = append(log, "converge "+f.name)
+						return nil
+					},
+				}
+				if !f.noUndo {
+					s.undo = func() error {
+						log = append(log, "undo "+f.name)
+						if f.failUndo {
+							return errors.New("undo " + f.name + " failed")
+						}
+						return nil
+					}
+				}
+				steps = append(steps, s)
+			}
+
+			err := runSteps(steps, tt.rollback)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("runSteps: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("runSteps error = %v, want %q", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(log, tt.wantLog) {
+				t.Errorf("runSteps ran %q, want %q", log, tt.wantLog)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/robreris/gh-jenkins-cli/github"
+	"github.com/spf13/cobra"
+)
+
+var collabsFile string
+
+var syncCollabsCmd = &cobra.Command{
+	Use:   "sync-collabs",
+	Short: "Make a repository's collaborators match a file",
+	Long: `Compare a repository's direct collaborators with a file listing the desired
+ones, then add the missing collaborators, change permissions that differ and
+remove everyone not in the file. Pending invitations count as collaborators;
+expired ones are replaced with a new invitation. A custom repository role is
+compared by its base permission and kept while that matches the file.
+
+The changes are printed and applied after confirmation; --yes skips the
+question and --dry-run only prints them.
+
+The file has one collaborator per line, optionally with a permission
+(pull, triage, push, maintain or admin; read and write also work):
+
+  # workshop staff
+  user1
+  user2:maintain
+
+Example usage:
+  gh-jenkins-cli sync-collabs -r my-repo --file desired.txt --dry-run
+  gh-jenkins-cli sync-collabs -r my-repo --file desired.txt --yes
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		desired, err := github.LoadDesiredCollaborators(collabsFile, permission)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		client := github.NewClient()
+		actual, err := client.ListCollaborators(orgName, repoName)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		changes := github.DiffCollaborators(actual, desired)
+		if len(changes) == 0 {
+			fmt.Printf("Collaborators of repository '%s' already match %s.\n", repoName, collabsFile)
+			return
+		}
+
+		for _, ch := range changes {
+			fmt.Println(ch)
+		}
+
+		if dryRun {
+			fmt.Printf("%d changes would be made.\n", len(changes))
+			return
+		}
+		if !assumeYes && !confirm(fmt.Sprintf("Apply these changes to repository '%s'?", repoName)) {
+			fmt.Println("Sync cancelled.")
+			return
+		}
+
+		failed := 0
+		for _, ch := range changes {
+			if err := client.ApplyCollaboratorChange(orgName, repoName, ch); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				failed++
+			}
+		}
+		fmt.Printf("Applied %d changes, %d failed.\n", len(changes)-failed, failed)
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(syncCollabsCmd)
+	syncCollabsCmd.Flags().StringVarP(&repoName, "repo-name", "r", "", "GitHub repository name (required)")
+	syncCollabsCmd.Flags().StringVarP(&collabsFile, "file", "f", "", "File listing the desired collaborators (required)")
+	syncCollabsCmd.Flags().StringVarP(&permission, "permission", "p", "push", "Permission for collaborators listed without one")
+	syncCollabsCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the changes without making them")
+	syncCollabsCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Apply changes without asking for confirmation")
+	addOrgFlag(syncCollabsCmd)
+	syncCollabsCmd.MarkFlagRequired("repo-name")
+	syncCollabsCmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/robreris/gh-jenkins-cli/github"
+	"github.com/robreris/gh-jenkins-cli/jenkins"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportDir      string
+	forceOverwrite bool
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Work with the built-in Jenkins job and Jenkinsfile templates",
+}
+
+var templatesExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write the built-in templates to a directory for customization",
+	Long: `Write the built-in Jenkins job config XML templates (pipeline and
+multibranch) and Jenkinsfile to a directory. Edit them and pass them back with --config-xml/--jenkins-xml and
+--jenkinsfile.
+
+Example usage:
+  gh-jenkins-cli templates export -d my-templates
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		templates := map[string]string{
+			"template-config.xml":      jenkins.DefaultJobTemplate,
+			"template-multibranch.xml": jenkins.DefaultMultibranchTemplate,
+			"Jenkinsfile":              github.DefaultJenkinsfile,
+		}
+
+		if err := os.MkdirAll(exportDir, 0755); err != nil {
+			log.Fatal("Error creating export directory: ", err)
+		}
+
+		for name, content := range templates {
+			path := filepath.Join(exportDir, name)
+
+			if _, err := os.Stat(path); err == nil && !forceOverwrite {
+				log.Fatalf("Error exporting templates: %s already exists (use --force to overwrite)", path)
+			}
+
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				log.Fatalf("Error writing %s: %v", path, err)
+			}
+			fmt.Printf("Wrote %s\n", path)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(templatesCmd)
+	templatesCmd.AddCommand(templatesExportCmd)
+	templatesExportCmd.Flags().StringVarP(&exportDir, "dir", "d", "templates", "Directory to write the templates to.")
+	templatesExportCmd.Flags().BoolVarP(&forceOverwrite, "force", "f", false, "Overwrite existing files.")
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/robreris/gh-jenkins-cli/jenkins"
+	"github.com/spf13/cobra"
+)
+
+var assumeYes bool
+
+var updateJobCmd = &cobra.Command{
+	Use:   "update-job",
+	Short: "Update an existing Jenkins job from the config XML template",
+	Long: `Render the config XML template for a job, show how it differs from the job's
+live configuration in Jenkins and apply it after confirmation.
+
+Both configs are normalized before comparing, so formatting-only differences
+are ignored.
+
+Example usage:
+  gh-jenkins-cli update-job -n my-repo
+  gh-jenkins-cli update-job -n workshops/2026/my-repo -c path/to/config.xml --yes
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := jenkins.NewAPIClient()
+		job := jenkins.ParseJobPath(jobName)
+
+		rendered, err := renderJobConfig(configXMLPath, jobTemplateData(job))
+		if err != nil {
+			log.Fatal("Error rendering Jenkins job config: ", err)
+		}
+
+		live, err := client.GetJobConfig(job)
+		if err != nil {
+			log.Fatal("Error fetching Jenkins job config: ", err)
+		}
+
+		renderedXML, err := jenkins.NormalizeXML(rendered)
+		if err != nil {
+			log.Fatal("Error parsing rendered job config: ", err)
+		}
+		liveXML, err := jenkins.NormalizeXML(live)
+		if err != nil {
+			log.Fatal("Error parsing live job config: ", err)
+		}
+
+		templateName := configXMLPath
+		if templateName == "" {
+			templateName = "built-in template"
+		}
+
+		if !printDiff(os.Stdout, "jenkins:"+job.String(), templateName, liveXML, renderedXML) {
+			fmt.Printf("Jenkins job '%s' is already up to date.\n", job)
+			return
+		}
+
+		if !assumeYes && !confirm(fmt.Sprintf("Apply these changes to Jenkins job '%s'?", job)) {
+			fmt.Println("Update cancelled.")
+			return
+		}
+
+		if err := client.UpdateJobConfig(job, rendered); err != nil {
+			log.Fatal("Error updating Jenkins job: ", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(updateJobCmd)
+	updateJobCmd.Flags().StringVarP(&jobName, "name", "n", "", "Name of Jenkins job, including any folders (e.g. workshops/2026/my-repo).")
+	updateJobCmd.Flags().StringVarP(&configXMLPath, "config-xml", "c", "", "Path to config XML template. Defaults to the built-in template.")
+	addJobTemplateFlags(updateJobCmd)
+	addJobRepoFlags(updateJobCmd)
+	updateJobCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Apply changes without asking for confirmation.")
+	updateJobCmd.MarkFlagRequired("name")
+}
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/robreris/gh-jenkins-cli/github"
+	"github.com/robreris/gh-jenkins-cli/jenkins"
+	"github.com/spf13/cobra"
+)
+
+var (
+	webhookURL    string
+	webhookID     int64
+	webhookEvents []string
+	webhookActive bool
+	webhookNewURL string
+	deliveryLimit int
+)
+
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Manage the GitHub webhooks of a repo",
+	Long: `Manage repo webhooks. Commands that act on a single hook select it by --id, or
+by --url, which defaults to the Jenkins GitHub plugin endpoint
+($JENKINS_URL/github-webhook/).`,
+}
+
+var webhookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the webhooks of a repo",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := github.NewClient()
+
+		hooks, err := client.ListWebhooks(orgName, repoName)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tURL\tEVENTS\tACTIVE\tLAST RESPONSE")
+		for _, hook := range hooks {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%t\t%s\n", hook.ID, hook.URL, strings.Join(hook.Events, ","), hook.Active, hook.LastResponse)
+		}
+		w.Flush()
+	},
+}
+
+var webhookCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a webhook, or update the existing one with the same URL",
+	Long: `Create a webhook. If the repo already has a hook with the same URL it is
+updated instead, so running create twice never adds a duplicate.
+
+Jenkins webhooks are signed with the secret in the Jenkins credential given by
+--credentials-id; without --webhook-secret a secret is generated if that
+credential doesn't exist yet.
+
+Example usage:
+  gh-jenkins-cli webhook create -r my-repo
+  gh-jenkins-cli webhook create -r my-repo --url https://example.com/hook --events push,pull_request
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := github.NewClient()
+		opts := github.WebhookOptions{URL: webhookTarget(client), Events: webhookEvents, Active: webhookActive}
+
+		// Jenkins hooks share the secret in the Jenkins credential; other
+		// hooks just get --webhook-secret, if any.
+		var err error
+		if client.IsJenkinsWebhook(opts.URL) {
+			opts.Secret, err = resolveWebhookSecret(jenkins.NewAPIClient())
+		} else {
+			opts.Secret, err = givenWebhookSecret()
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		existing, err := client.FindWebhook(orgName, repoName, opts.URL)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if existing != nil {
+			if _, err := client.UpdateWebhook(orgName, repoName, existing.ID, opts); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("Webhook %d for '%s' already exists; updated it.\n", existing.ID, opts.URL)
+			return
+		}
+
+		hook, err := client.AddWebhook(orgName, repoName, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Webhook %d created for '%s'.\n", hook.ID, opts.URL)
+	},
+}
+
+var webhookUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Change the URL, events or active state of a webhook",
+	Long: `Change a webhook. Only the settings given on the command line change. GitHub
+drops the secret when the URL changes; run rotate-secret afterwards to set it
+again on a Jenkins webhook.
+
+Example usage:
+  gh-jenkins-cli webhook update -r my-repo --events push,pull_request
+  gh-jenkins-cli webhook update -r my-repo --id 12345 --new-url https://jenkins.example.com/github-webhook/
+  gh-jenkins-cli webhook update -r my-repo --active=false
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := github.NewClient()
+		hook := selectedWebhook(client)
+
+		opts := hook.Options()
+		if cmd.Flags().Changed("new-url") {
+			opts.URL = webhookNewURL
+		}
+		if cmd.Flags().Changed("events") {
+			opts.Events = webhookEvents
+		}
+		if cmd.Flags().Changed("active") {
+			opts.Active = webhookActive
+		}
+
+		if _, err := client.UpdateWebhook(orgName, repoName, hook.ID, opts); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Webhook %d updated.\n", hook.ID)
+	},
+}
+
+var webhookDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a webhook",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := github.NewClient()
+		hook := selectedWebhook(client)
+
+		if err := client.DeleteWebhook(orgName, repoName, hook.ID); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Webhook %d for '%s' deleted.\n", hook.ID, hook.URL)
+	},
+}
+
+var webhookPingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Send a ping event to a webhook",
+	Long:  `Send a ping event to a webhook. Check the result with "webhook deliveries".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := github.NewClient()
+		hook := selectedWebhook(client)
+
+		if err := client.PingWebhook(orgName, repoName, hook.ID); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Ping sent to webhook %d.\n", hook.ID)
+	},
+}
+
+var webhookDeliveriesCmd = &cobra.Command{
+	Use:   "deliveries",
+	Short: "Show the recent deliveries of a webhook",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := github.NewClient()
+		hook := selectedWebhook(client)
+
+		deliveries, err := client.ListWebhookDeliveries(orgName, repoName, hook.ID, deliveryLimit, time.Time{})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if len(deliveries) == 0 {
+			fmt.Println("No deliveries yet.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tDELIVERED\tEVENT\tSTATUS\tDURATION\tREDELIVERY")
+		for _, d := range deliveries {
+			event := d.Event
+			if d.Action != "" {
+				event += "." + d.Action
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d %s\t%s\t%t\n", d.ID, d.DeliveredAt.Local().Format(time.DateTime),
+				event, d.StatusCode, d.Status, d.Duration.Round(time.Millisecond), d.Redelivery)
+		}
+		w.Flush()
+	},
+}
+
+// webhookTarget returns --url, defaulting to the Jenkins webhook endpoint.
+func webhookTarget(client *github.Client) string {
+	if webhookURL != "" {
+		return webhookURL
+	}
+	return client.JenkinsWebhookURL()
+}
+
+// selectedWebhook returns the hook chosen by --id, or else by --url.
+func selectedWebhook(client *github.Client) *github.Webhook {
+	if webhookID != 0 {
+		hook, err := client.GetWebhook(orgName, repoName, webhookID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return hook
+	}
+
+	url := webhookTarget(client)
+	hook, err := client.FindWebhook(orgName, repoName, url)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if hook == nil {
+		log.Fatalf("Repository '%s' has no webhook for '%s'.", repoName, url)
+	}
+	return hook
+}
+
+// selectedRepos returns the repo given by --repo-name, or every repo in the
+// org with --all-repos.
+func selectedRepos(client *github.Client) []string {
+	if (repoName == "") == !allRepos {
+		log.Fatal("Give either --repo-name or --all-repos.")
+	}
+	if !allRepos {
+		return []string{repoName}
+	}
+
+	all, err := client.ListOrgRepos(orgName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var repos []string
+	for _, r := range all {
+		repos = append(repos, r.GetName())
+	}
+	return repos
+}
+
+func addWebhookSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64Var(&webhookID, "id", 0, "Webhook ID, as shown by webhook list.")
+	cmd.Flags().StringVar(&webhookURL, "url", "", "Webhook URL. Defaults to $JENKINS_URL/github-webhook/.")
+}
+
+func init() {
+	rootCmd.AddCommand(webhookCmd)
+	webhookCmd.AddCommand(webhookListCmd, webhookCreateCmd, webhookUpdateCmd, webhookDeleteCmd, webhookPingCmd, webhookDeliveriesCmd)
+
+	for _, cmd := range webhookCmd.Commands() {
+		cmd.Flags().StringVarP(&repoName, "repo-name", "r", "", "GitHub repository name (required)")
+		addOrgFlag(cmd)
+		cmd.MarkFlagRequired("repo-name")
+	}
+
+	webhookCreateCmd.Flags().StringVar(&webhookURL, "url", "", "Webhook URL. Defaults to $JENKINS_URL/github-webhook/.")
+	for _, cmd := range []*cobra.Command{webhookCreateCmd, webhookUpdateCmd} {
+		cmd.Flags().StringSliceVar(&webhookEvents, "events", []string{"push"}, "Comma-separated events that trigger the webhook.")
+		cmd.Flags().BoolVar(&webhookActive, "active", true, "Whether GitHub delivers events to the webhook.")
+	}
+	webhookUpdateCmd.Flags().StringVar(&webhookNewURL, "new-url", "", "New URL for the webhook.")
+	addWebhookSecretFlags(webhookCreateCmd, "credentials-id")
+
+	for _, cmd := range []*cobra.Command{webhookUpdateCmd, webhookDeleteCmd, webhookPingCmd, webhookDeliveriesCmd} {
+		addWebhookSelectionFlags(cmd)
+	}
+	webhookDeliveriesCmd.Flags().IntVarP(&deliveryLimit, "limit", "l", 30, "Number of deliveries to show; 0 shows all that GitHub keeps.")
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/robreris/gh-jenkins-cli/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	redeliverSince time.Duration
+	dryRun         bool
+)
+
+var webhookRedeliverCmd = &cobra.Command{
+	Use:   "redeliver",
+	Short: "Resend webhook deliveries that failed, e.g. while Jenkins was down",
+	Long: `Find the deliveries of a repo's Jenkins webhook (or --url) made within --since
+that did not get a 2xx response, and ask GitHub to send them again. An event is
+skipped if any attempt to deliver it succeeded. GitHub keeps deliveries for 3
+days.
+
+Example usage:
+  gh-jenkins-cli webhook redeliver -r my-repo
+  gh-jenkins-cli webhook redeliver --all-repos --since 6h --dry-run
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client := github.NewClient()
+		repos := selectedRepos(client)
+
+		hookURL := webhookTarget(client)
+		since := time.Now().Add(-redeliverSince)
+		redelivered, failed := 0, 0
+		for _, repo := range repos {
+			hook, err := client.FindWebhook(orgName, repo, hookURL)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				failed++
+				continue
+			}
+			if hook == nil {
+				if !allRepos {
+					log.Fatalf("Repository '%s' has no webhook for '%s'.", repo, hookURL)
+				}
+				continue
+			}
+
+			deliveries, err := client.ListWebhookDeliveries(orgName, repo, hook.ID, 0, since)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				failed++
+				continue
+			}
+
+			for _, d := range failedDeliveries(deliveries) {
+				fmt.Printf("%s: %s delivery %d at %s got %d %s\n", repo, d.Event, d.ID,
+					d.DeliveredAt.Local().Format(time.DateTime), d.StatusCode, d.Status)
+				if dryRun {
+					redelivered++
+					continue
+				}
+				if err := client.RedeliverWebhookDelivery(orgName, repo, hook.ID, d.ID); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					failed++
+					continue
+				}
+				redelivered++
+			}
+		}
+
+		if dryRun {
+			fmt.Printf("%d deliveries would be redelivered, %d repos failed.\n", redelivered, failed)
+		} else {
+			fmt.Printf("Redelivered %d deliveries, %d failed.\n", redelivered, failed)
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// failedDeliveries returns the newest attempt of each event that was never
+// delivered successfully. Redeliveries share the GUID of the original
+// delivery. The deliveries must be ordered newest first.
+func failedDeliveries(deliveries []*github.WebhookDelivery) []*github.WebhookDelivery {
+	succeeded := make(map[string]bool)
+	for _, d := range deliveries {
+		if d.Succeeded() {
+			succeeded[d.GUID] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	var failed []*github.WebhookDelivery
+	for _, d := range deliveries {
+		if succeeded[d.GUID] || seen[d.GUID] {
+			continue
+		}
+		seen[d.GUID] = true
+		failed = append(failed, d)
+	}
+	return failed
+}
+
+func init() {
+	webhookCmd.AddCommand(webhookRedeliverCmd)
+	webhookRedeliverCmd.Flags().StringVarP(&repoName, "repo-name", "r", "", "GitHub repository name")
+	webhookRedeliverCmd.Flags().BoolVar(&allRepos, "all-repos", false, "Redeliver failed deliveries for every repo in the org.")
+	webhookRedeliverCmd.Flags().StringVar(&webhookURL, "url", "", "Webhook URL. Defaults to $JENKINS_URL/github-webhook/.")
+	webhookRedeliverCmd.Flags().DurationVar(&redeliverSince, "since", 24*time.Hour, "Only consider deliveries made within this long, e.g. 6h.")
+	webhookRedeliverCmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the deliveries that would be redelivered without sending them.")
+	addOrgFlag(webhookRedeliverCmd)
+}
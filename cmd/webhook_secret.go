@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/robreris/gh-jenkins-cli/github"
+	"github.com/robreris/gh-jenkins-cli/jenkins"
+	"github.com/spf13/cobra"
+)
+
+// defaultWebhookCredentialsID is the Jenkins secret text credential holding
+// the webhook secret shared by every repo.
+const defaultWebhookCredentialsID = "github-webhook-secret"
+
+var (
+	webhookSecret        string
+	webhookCredentialsID string
+	allRepos             bool
+)
+
+var webhookRotateSecretCmd = &cobra.Command{
+	Use:   "rotate-secret",
+	Short: "Replace the webhook secret in Jenkins and on the repos' Jenkins webhooks",
+	Long: `Generate a new webhook secret (or use --webhook-secret), store it in the Jenkins
+credential and set it on the Jenkins webhook of one repo or of every repo in
+the org. Repos without a Jenkins webhook are skipped.
+
+Deliveries made between updating Jenkins and updating a repo's hook fail
+signature checks; use "webhook redeliver" to resend them.
+
+The secret is shared by every repo by default, so rotating it for a single repo
+breaks the others until they are rotated too. Use --all-repos, or give the repo
+its own --credentials-id.
+
+The credential is added to the GitHub plugin's shared secrets in Jenkins if it
+isn't listed there yet. That goes through the script console and needs admin
+rights; without them a warning asks to add it by hand.
+
+Example usage:
+  gh-jenkins-cli webhook rotate-secret --all-repos
+  gh-jenkins-cli webhook rotate-secret -r my-repo --credentials-id my-repo-webhook-secret
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ghClient := github.NewClient()
+		repos := selectedRepos(ghClient)
+		if !allRepos && webhookCredentialsID == defaultWebhookCredentialsID {
+			fmt.Fprintf(os.Stderr, "Warning: '%s' is shared by all repos; their webhooks keep the old secret until rotated.\n", webhookCredentialsID)
+		}
+
+		secret, err := givenWebhookSecret()
+		if err == nil && secret == "" {
+			secret, err = generateWebhookSecret()
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if _, err := saveWebhookSecret(jenkins.NewAPIClient(), secret); err != nil {
+			log.Fatal(err)
+		}
+
+		hookURL := ghClient.JenkinsWebhookURL()
+		rotated, failed := 0, 0
+		for _, repo := range repos {
+			hook, err := ghClient.FindWebhook(orgName, repo, hookURL)
+			if err == nil && hook == nil {
+				continue
+			}
+			if err == nil {
+				opts := hook.Options()
+				opts.Secret = secret
+				_, err = ghClient.UpdateWebhook(orgName, repo, hook.ID, opts)
+			}
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				failed++
+				continue
+			}
+			fmt.Printf("Secret rotated on the Jenkins webhook of '%s'.\n", repo)
+			rotated++
+		}
+
+		fmt.Printf("Rotated %d webhooks, %d failed.\n", rotated, failed)
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// resolveWebhookSecret returns the secret for a new Jenkins webhook and makes
+// sure the Jenkins credential holds it.
+func resolveWebhookSecret(jc *jenkins.APIClient) (string, error) {
+	secret, err := chooseWebhookSecret(jc)
+	if err != nil {
+		return "", err
+	}
+	_, err = saveWebhookSecret(jc, secret)
+	return secret, err
+}
+
+// chooseWebhookSecret returns --webhook-secret, or else a generated secret
+// unless the Jenkins credential already exists: Jenkins never reveals it, and
+// replacing it would break the webhooks already using it. Nothing is saved.
+func chooseWebhookSecret(jc *jenkins.APIClient) (string, error) {
+	secret, err := givenWebhookSecret()
+	if err != nil || secret != "" {
+		return secret, err
+	}
+
+	exists, err := jc.CredentialExists(jenkins.CredentialStore{}, webhookCredentialsID)
+	if err != nil {
+		return "", fmt.Errorf("error checking Jenkins credential '%s': %v", webhookCredentialsID, err)
+	}
+	if exists {
+		return "", fmt.Errorf("Jenkins credential '%s' already holds the webhook secret; pass it with --webhook-secret or GITHUB_WEBHOOK_SECRET", webhookCredentialsID)
+	}
+
+	return generateWebhookSecret()
+}
+
+// generateWebhookSecret generates a webhook secret and prints it once to
+// stderr. Jenkins never reveals it again, and later create-project runs need
+// it to sign new hooks.
+func generateWebhookSecret() (string, error) {
+	secret, err := github.GenerateWebhookSecret()
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(os.Stderr, "Generated webhook secret: %s\n", secret)
+	fmt.Fprintln(os.Stderr, "Store it now, e.g. as GITHUB_WEBHOOK_SECRET: it can't be read back from Jenkins, and later runs need it.")
+	return secret, nil
+}
+
+// givenWebhookSecret returns --webhook-secret, or GITHUB_WEBHOOK_SECRET if the
+// flag is not set. The environment is read here rather than as the flag
+// default so the secret never shows up in --help.
+func givenWebhookSecret() (string, error) {
+	value := webhookSecret
+	if value == "" {
+		value = os.Getenv("GITHUB_WEBHOOK_SECRET")
+	}
+	if value == "" {
+		return "", nil
+	}
+	return readSecret(value)
+}
+
+// saveWebhookSecret stores the webhook secret in the Jenkins system store and
+// reports whether it created the credential. It also registers the credential
+// with the GitHub plugin, which otherwise doesn't check signatures with it.
+func saveWebhookSecret(jc *jenkins.APIClient, secret string) (bool, error) {
+	created, err := jc.SaveCredential(jenkins.CredentialStore{}, jenkins.CredentialSpec{
+		Type:        jenkins.SecretText,
+		ID:          webhookCredentialsID,
+		Description: "GitHub webhook shared secret",
+		Secret:      secret,
+	})
+	if err != nil {
+		return false, fmt.Errorf("error saving webhook secret in Jenkins credential '%s': %v", webhookCredentialsID, err)
+	}
+
+	if created {
+		fmt.Printf("Webhook secret stored in new Jenkins credential '%s'.\n", webhookCredentialsID)
+	} else {
+		fmt.Printf("Webhook secret updated in Jenkins credential '%s'.\n", webhookCredentialsID)
+	}
+
+	added, err := jc.EnsureHookSecret(webhookCredentialsID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not check the GitHub plugin's shared secrets: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Make sure '%s' is listed under Manage Jenkins > System > GitHub > Advanced > Shared secrets.\n", webhookCredentialsID)
+	} else if added {
+		fmt.Printf("Added Jenkins credential '%s' to the GitHub plugin's shared secrets.\n", webhookCredentialsID)
+	}
+	return created, nil
+}
+
+// addWebhookSecretFlags registers --webhook-secret and the credential ID flag,
+// which create-project calls --webhook-credentials-id to keep it apart from
+// the job's checkout --credentials-id.
+func addWebhookSecretFlags(cmd *cobra.Command, credentialsFlag string) {
+	cmd.Flags().StringVar(&webhookSecret, "webhook-secret", "", `Webhook secret, or "-" to read it from stdin. GITHUB_WEBHOOK_SECRET sets the default; otherwise one is generated.`)
+	cmd.Flags().StringVar(&webhookCredentialsID, credentialsFlag, defaultWebhookCredentialsID, "Jenkins secret text credential holding the webhook secret.")
+}
+
+func init() {
+	webhookCmd.AddCommand(webhookRotateSecretCmd)
+	webhookRotateSecretCmd.Flags().StringVarP(&repoName, "repo-name", "r", "", "GitHub repository name")
+	webhookRotateSecretCmd.Flags().BoolVar(&allRepos, "all-repos", false, "Rotate the secret on every repo in the org.")
+	addOrgFlag(webhookRotateSecretCmd)
+	addWebhookSecretFlags(webhookRotateSecretCmd, "credentials-id")
+}
@@ -0,0 +1,285 @@
+package github
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// Collaborator is a user with direct access to a repo, or with a pending
+// invitation to it.
+type Collaborator struct {
+	Login      string
+	Permission string // one of RepoPermissions
+	Role       string // custom repository role, if any; Permission is its base permission
+	Pending    bool
+	Expired    bool // the pending invitation expired; the user has no access
+
+	invitationID int64
+}
+
+// normalizePermission maps the role names GitHub reports (read, write) to
+// the permission names it accepts when granting access (pull, push).
+func normalizePermission(permission string) string {
+	switch permission {
+	case "read":
+		return "pull"
+	case "write":
+		return "push"
+	default:
+		return permission
+	}
+}
+
+// invitationPermission maps a permission to the name the invitations API
+// uses for it.
+func invitationPermission(permission string) string {
+	switch permission {
+	case "pull":
+		return "read"
+	case "push":
+		return "write"
+	default:
+		return permission
+	}
+}
+
+// collaboratorPermission returns the permission of a collaborator with the
+// given role. Custom repository roles map to the highest base permission
+// they include, and are returned as the role.
+func collaboratorPermission(roleName string, permissions map[string]bool) (permission string, role string) {
+	permission = normalizePermission(roleName)
+	if slices.Contains(RepoPermissions, permission) {
+		return permission, ""
+	}
+	for i := len(RepoPermissions) - 1; i >= 0; i-- {
+		if permissions[RepoPermissions[i]] {
+			return RepoPermissions[i], roleName
+		}
+	}
+	return "", roleName
+}
+
+func validatePermission(permission string) error {
+	if !slices.Contains(RepoPermissions, permission) {
+		return fmt.Errorf("invalid permission %q: use one of %s", permission, strings.Join(RepoPermissions, ", "))
+	}
+	return nil
+}
+
+// listInvitations returns a repo's pending invitations.
+func (c *Client) listInvitations(owner string, repo string) ([]*github.RepositoryInvitation, error) {
+	ctx := context.Background()
+
+	var all []*github.RepositoryInvitation
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		invitations, resp, err := c.client.Repositories.ListInvitations(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error listing invitations for repository '%s': %v", repo, err)
+		}
+		all = append(all, invitations...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ListCollaborators returns the users given access to a repo directly, rather
+// than through org membership or teams, followed by pending invitations,
+// including expired ones.
+func (c *Client) ListCollaborators(owner string, repo string) ([]*Collaborator, error) {
+	ctx := context.Background()
+
+	var all []*Collaborator
+	opts := &github.ListCollaboratorsOptions{Affiliation: "direct", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		users, resp, err := c.client.Repositories.ListCollaborators(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error listing collaborators of repository '%s': %v", repo, err)
+		}
+		for _, u := range users {
+			permission, role := collaboratorPermission(u.GetRoleName(), u.GetPermissions())
+			all = append(all, &Collaborator{Login: u.GetLogin(), Permission: permission, Role: role})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	invitations, err := c.listInvitations(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	for _, inv := range invitations {
+		all = append(all, &Collaborator{
+			Login:        inv.GetInvitee().GetLogin(),
+			Permission:   normalizePermission(inv.GetPermissions()),
+			Pending:      true,
+			Expired:      inv.GetExpired(),
+			invitationID: inv.GetID(),
+		})
+	}
+	return all, nil
+}
+
+// RemoveCollaborator revokes a collaborator's access, or cancels their
+// invitation if it is still pending.
+func (c *Client) RemoveCollaborator(owner string, repo string, collab *Collaborator) error {
+	ctx := context.Background()
+
+	var err error
+	if collab.Pending {
+		_, err = c.client.Repositories.DeleteInvitation(ctx, owner, repo, collab.invitationID)
+	} else {
+		_, err = c.client.Repositories.RemoveCollaborator(ctx, owner, repo, collab.Login)
+	}
+	if err != nil {
+		return fmt.Errorf("error removing collaborator %s from repository '%s': %v", collab.Login, repo, err)
+	}
+	return nil
+}
+
+// SetCollaboratorPermission changes a collaborator's permission, or the
+// permission of their pending invitation.
+func (c *Client) SetCollaboratorPermission(owner string, repo string, collab *Collaborator, permission string) error {
+	ctx := context.Background()
+
+	var err error
+	if collab.Pending {
+		_, _, err = c.client.Repositories.UpdateInvitation(ctx, owner, repo, collab.invitationID, invitationPermission(permission))
+	} else {
+		_, _, err = c.client.Repositories.AddCollaborator(ctx, owner, repo, collab.Login,
+			&github.RepositoryAddCollaboratorOptions{Permission: permission})
+	}
+	if err != nil {
+		return fmt.Errorf("error changing permission of collaborator %s on repository '%s': %v", collab.Login, repo, err)
+	}
+	return nil
+}
+
+// LoadDesiredCollaborators reads a collaborators file: one "login" or
+// "login:permission" per line, with blank lines and lines starting with #
+// ignored. Logins without a permission get defaultPermission.
+func LoadDesiredCollaborators(path string, defaultPermission string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening collaborators file: %v", err)
+	}
+	defer f.Close()
+
+	desired := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		login, permission, found := strings.Cut(line, ":")
+		login = strings.TrimSpace(login)
+		permission = normalizePermission(strings.TrimSpace(permission))
+		if !found {
+			permission = normalizePermission(defaultPermission)
+		}
+		if login == "" {
+			return nil, fmt.Errorf("%s:%d: missing login", path, lineNo)
+		}
+		if err := validatePermission(permission); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, lineNo, err)
+		}
+		desired[login] = permission
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading collaborators file: %v", err)
+	}
+	return desired, nil
+}
+
+// CollaboratorChange is one difference between desired and actual
+// collaborators. Current is nil for additions, or an expired invitation to
+// replace; Permission is "" for removals.
+type CollaboratorChange struct {
+	Login      string
+	Current    *Collaborator
+	Permission string
+}
+
+func (ch CollaboratorChange) String() string {
+	switch {
+	case ch.Current == nil:
+		return fmt.Sprintf("+ %s (%s)", ch.Login, ch.Permission)
+	case ch.Current.Expired && ch.Permission != "":
+		return fmt.Sprintf("+ %s (%s, replacing expired invitation)", ch.Login, ch.Permission)
+	case ch.Permission == "":
+		return fmt.Sprintf("- %s (%s)", ch.Login, ch.Current.access())
+	default:
+		return fmt.Sprintf("~ %s (%s -> %s)", ch.Login, ch.Current.access(), ch.Permission)
+	}
+}
+
+// access describes the collaborator's permission, naming any custom role.
+func (c *Collaborator) access() string {
+	if c.Role != "" {
+		return fmt.Sprintf("%s, role %s", c.Permission, c.Role)
+	}
+	return c.Permission
+}
+
+// DiffCollaborators returns the changes that turn actual into desired, which
+// maps logins to permissions. Logins are compared case-insensitively, and
+// custom roles by their base permission, so a role is kept while it matches.
+// Users whose invitation expired are invited again.
+func DiffCollaborators(actual []*Collaborator, desired map[string]string) []CollaboratorChange {
+	current := make(map[string]*Collaborator)
+	for _, collab := range actual {
+		current[strings.ToLower(collab.Login)] = collab
+	}
+
+	var changes []CollaboratorChange
+	wanted := make(map[string]bool)
+	for login, permission := range desired {
+		wanted[strings.ToLower(login)] = true
+		collab := current[strings.ToLower(login)]
+		if collab == nil {
+			changes = append(changes, CollaboratorChange{Login: login, Permission: permission})
+		} else if collab.Expired || collab.Permission != permission {
+			changes = append(changes, CollaboratorChange{Login: collab.Login, Current: collab, Permission: permission})
+		}
+	}
+	for _, collab := range actual {
+		if !wanted[strings.ToLower(collab.Login)] {
+			changes = append(changes, CollaboratorChange{Login: collab.Login, Current: collab})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return strings.ToLower(changes[i].Login) < strings.ToLower(changes[j].Login) })
+	return changes
+}
+
+// ApplyCollaboratorChange makes one change from DiffCollaborators.
+func (c *Client) ApplyCollaboratorChange(owner string, repo string, ch CollaboratorChange) error {
+	switch {
+	case ch.Current == nil:
+		_, err := c.AddCollaborators(owner, repo, []string{ch.Login}, ch.Permission)
+		return err
+	case ch.Current.Expired && ch.Permission != "":
+		if err := c.RemoveCollaborator(owner, repo, ch.Current); err != nil {
+			return err
+		}
+		_, err := c.AddCollaborators(owner, repo, []string{ch.Login}, ch.Permission)
+		return err
+	case ch.Permission == "":
+		return c.RemoveCollaborator(owner, repo, ch.Current)
+	default:
+		return c.SetCollaboratorPermission(owner, repo, ch.Current, ch.Permission)
+	}
+}
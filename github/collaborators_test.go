@@ -0,0 +1,118 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCollaboratorPermission(t *testing.T) {
+	tests := []struct {
+		name           string
+		roleName       string
+		permissions    map[string]bool
+		wantPermission string
+		wantRole       string
+	}{
+		{name: "base role", roleName: "maintain", permissions: map[string]bool{"maintain": true, "push": true}, wantPermission: "maintain"},
+		{name: "read alias", roleName: "read", permissions: map[string]bool{"pull": true}, wantPermission: "pull"},
+		{name: "write alias", roleName: "write", permissions: map[string]bool{"push": true, "pull": true}, wantPermission: "push"},
+		{
+			name:           "custom role maps to its highest base permission",
+			roleName:       "security-reviewer",
+			permissions:    map[string]bool{"admin": false, "maintain": false, "push": true, "triage": true, "pull": true},
+			wantPermission: "push",
+			wantRole:       "security-reviewer",
+		},
+		{name: "custom role without permissions", roleName: "nobody", wantRole: "nobody"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permission, role := collaboratorPermission(tt.roleName, tt.permissions)
+			if permission != tt.wantPermission || role != tt.wantRole {
+				t.Errorf("collaboratorPermission = (%q, %q), want (%q, %q)", permission, role, tt.wantPermission, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestDiffCollaborators(t *testing.T) {
+	tests := []struct {
+		name    string
+		actual  []*Collaborator
+		desired map[string]string
+		want    []string
+	}{
+		{
+			name:    "already in sync",
+			actual:  []*Collaborator{{Login: "alice", Permission: "push"}},
+			desired: map[string]string{"alice": "push"},
+		},
+		{
+			name:    "add",
+			desired: map[string]string{"alice": "push", "bob": "admin"},
+			want:    []string{"+ alice (push)", "+ bob (admin)"},
+		},
+		{
+			name:   "remove",
+			actual: []*Collaborator{{Login: "alice", Permission: "push"}, {Login: "bob", Permission: "pull", Pending: true}},
+			want:   []string{"- alice (push)", "- bob (pull)"},
+		},
+		{
+			name:    "change permission",
+			actual:  []*Collaborator{{Login: "alice", Permission: "push"}},
+			desired: map[string]string{"alice": "maintain"},
+			want:    []string{"~ alice (push -> maintain)"},
+		},
+		{
+			name:    "logins compare case-insensitively",
+			actual:  []*Collaborator{{Login: "Alice", Permission: "push"}, {Login: "Bob", Permission: "push"}},
+			desired: map[string]string{"alice": "push", "BOB": "admin"},
+			want:    []string{"~ Bob (push -> admin)"},
+		},
+		{
+			name:    "custom role matching its base permission is kept",
+			actual:  []*Collaborator{{Login: "alice", Permission: "push", Role: "security-reviewer"}},
+			desired: map[string]string{"alice": "push"},
+		},
+		{
+			name:    "custom role with another permission",
+			actual:  []*Collaborator{{Login: "alice", Permission: "push", Role: "security-reviewer"}},
+			desired: map[string]string{"alice": "admin"},
+			want:    []string{"~ alice (push, role security-reviewer -> admin)"},
+		},
+		{
+			name:    "expired invitation is replaced",
+			actual:  []*Collaborator{{Login: "alice", Permission: "push", Pending: true, Expired: true}},
+			desired: map[string]string{"alice": "push"},
+			want:    []string{"+ alice (push, replacing expired invitation)"},
+		},
+		{
+			name:   "unwanted expired invitation is removed",
+			actual: []*Collaborator{{Login: "alice", Permission: "push", Pending: true, Expired: true}},
+			want:   []string{"- alice (push)"},
+		},
+		{
+			name: "mixed changes are sorted by login",
+			actual: []*Collaborator{
+				{Login: "dave", Permission: "push"},
+				{Login: "carol", Permission: "pull"},
+				{Login: "alice", Permission: "push"},
+			},
+			desired: map[string]string{"Bob": "push", "carol": "triage", "alice": "push"},
+			want:    []string{"+ Bob (push)", "~ carol (pull -> triage)", "- dave (push)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, ch := range DiffCollaborators(tt.actual, tt.desired) {
+				got = append(got, ch.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffCollaborators = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
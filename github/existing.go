@@ -0,0 +1,116 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// isNotFound reports whether a GitHub API error is a 404.
+func isNotFound(err error) bool {
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound
+}
+
+func isForbidden(err error) bool {
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusForbidden
+}
+
+// FindGeneratedRepo looks up a repo that should have been generated from the
+// given template repo. It returns the repo's URL, or "" if the repo doesn't
+// exist, and an error if the repo exists but came from another template.
+func (c *Client) FindGeneratedRepo(orgName string, repoName string, templateOwner string, templateRepo string) (string, error) {
+	repo, _, err := c.client.Repositories.Get(context.Background(), orgName, repoName)
+	if isNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error fetching repository '%s': %v", repoName, err)
+	}
+
+	template := repo.GetTemplateRepository()
+	if template == nil || !strings.EqualFold(template.GetOwner().GetLogin(), templateOwner) || !strings.EqualFold(template.GetName(), templateRepo) {
+		return "", fmt.Errorf("repository '%s' already exists but was not generated from '%s/%s'", repoName, templateOwner, templateRepo)
+	}
+	return repo.GetHTMLURL(), nil
+}
+
+// PagesURL returns the URL of a repo's GitHub Pages site, or "" if Pages is
+// not enabled.
+func (c *Client) PagesURL(orgName string, repoName string) (string, error) {
+	pages, _, err := c.client.Repositories.GetPagesInfo(context.Background(), orgName, repoName)
+	if isNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error fetching GitHub Pages URL: %v", err)
+	}
+	return pages.GetHTMLURL(), nil
+}
+
+// RepoFilesMatch reports whether the main branch already has the README and,
+// if one is given, the Jenkinsfile that UpdateRepoFiles would commit.
+func (c *Client) RepoFilesMatch(orgName string, repoName string, readmeContent string, jenkinsfile string) (bool, error) {
+	files := map[string]string{"README.md": readmeContent}
+	if jenkinsfile != "" {
+		files["Jenkinsfile"] = jenkinsfile
+	}
+
+	for path, want := range files {
+		file, _, _, err := c.client.Repositories.GetContents(context.Background(), orgName, repoName, path,
+			&github.RepositoryContentGetOptions{Ref: "main"})
+		if isNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("error fetching %s: %v", path, err)
+		}
+
+		got, err := file.GetContent()
+		if err != nil {
+			return false, fmt.Errorf("error decoding %s: %v", path, err)
+		}
+		if got != want {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// MissingCollaborators returns the users who neither have access to the repo
+// nor a pending invitation to it. Expired invitations don't count, so those
+// users are invited again.
+func (c *Client) MissingCollaborators(owner string, repo string, users []string) ([]string, error) {
+	ctx := context.Background()
+
+	invitations, err := c.listInvitations(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	invited := make(map[string]bool)
+	for _, inv := range invitations {
+		if !inv.GetExpired() {
+			invited[strings.ToLower(inv.GetInvitee().GetLogin())] = true
+		}
+	}
+
+	var missing []string
+	for _, user := range users {
+		if invited[strings.ToLower(user)] {
+			continue
+		}
+		ok, _, err := c.client.Repositories.IsCollaborator(ctx, owner, repo, user)
+		if err != nil {
+			return nil, fmt.Errorf("error checking collaborator %s: %v", user, err)
+		}
+		if !ok {
+			missing = append(missing, user)
+		}
+	}
+	return missing, nil
+}
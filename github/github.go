@@ -7,11 +7,15 @@ import (
 	"github.com/google/go-github/v68/github"
 	"golang.org/x/oauth2"
 	"log"
+	"net/http"
 	"os"
-	"regexp"
+	"strings"
 	"time"
 )
 
+// JenkinsStatusCheck is the commit status Jenkins reports for builds.
+const JenkinsStatusCheck = "ci/jenkins/build-status"
+
 type Client struct {
 	client     *github.Client
 	JenkinsUrl string
@@ -43,9 +47,46 @@ func NewClient() *Client {
 	}
 }
 
-func (c *Client) CreateRepo(orgName string, name string, templateRepo string, private bool, enablePipeline bool) (*github.Repository, error) {
+// CreateRepoOptions describes a repo to generate from a template repo.
+type CreateRepoOptions struct {
+	Org           string // org (or user) that will own the new repo
+	Name          string
+	TemplateOwner string // defaults to Org
+	TemplateRepo  string
+	Private       bool
+
+	// Jenkinsfile is committed along with a webhook to Jenkins. If empty, the
+	// repo is created without a Jenkins pipeline.
+	Jenkinsfile string
+
+	// WebhookSecret signs the webhook's deliveries so Jenkins can verify them.
+	WebhookSecret string
+
+	// Protection is applied to the main branch once the repo is set up, as
+	// classic branch protection or, with UseRulesets, as a repo ruleset.
+	Protection  ProtectionProfile
+	UseRulesets bool
+	ProtectTags bool // rulesets only
+}
+
+func (o CreateRepoOptions) templateOwner() string {
+	if o.TemplateOwner == "" {
+		return o.Org
+	}
+	return o.TemplateOwner
+}
 
-	createdRepo, err := c.GenerateRepoFromTemplate(orgName, templateRepo, name, private)
+// OrgRulesetName names the org rulesets protecting the repos generated from
+// the options' template.
+func (o CreateRepoOptions) OrgRulesetName() string {
+	return TemplateRulesetName(o.templateOwner(), o.TemplateRepo)
+}
+
+// CreateRepo generates a repo from the template and sets it up.
+func (c *Client) CreateRepo(opts CreateRepoOptions) (*github.Repository, error) {
+	orgName, name := opts.Org, opts.Name
+
+	createdRepo, err := c.GenerateRepoFromTemplate(opts.templateOwner(), opts.TemplateRepo, orgName, name, opts.Private)
 	if err != nil {
 		return nil, err
 	}
@@ -61,44 +102,32 @@ func (c *Client) CreateRepo(orgName string, name string, templateRepo string, pr
 	}
 	fmt.Printf("GitHub Pages URL: %s\n", pagesURL)
 
-	readmeContent := fmt.Sprintf(`
-# %s
-
-To view the workshop, please go here: [GitHub Pages Link](%s)
-
----
-
-For more information on creating these workshops, visit [FortinetCloudCSE User Repo](https://fortinetcloudcse.github.io/UserRepo/)
-`, name, pagesURL)
+	readmeContent := ReadmeContent(name, pagesURL, opts.templateOwner(), opts.TemplateRepo)
 
-        //Need UpdateRepo in both blocks since order of execution is important here
-	if enablePipeline {
-		//webhookURL := "https://jenkins.fortinetcloudcse.com:8443/github-webhook/"
-		webhookURL := c.JenkinsUrl + "/github-webhook/"
-		err = c.CreateWebhook(orgName, name, webhookURL)
+	//Need UpdateRepo in both blocks since order of execution is important here
+	if opts.Jenkinsfile != "" {
+		_, err = c.CreateWebhook(orgName, name, c.JenkinsWebhookURL(), opts.WebhookSecret)
 		if err != nil {
 			return nil, fmt.Errorf("error creating webhook: %v", err)
 		}
 
-	        err = c.UpdateRepoFiles(orgName, name, readmeContent, enablePipeline)
-	        if err != nil {
-		        return nil, fmt.Errorf("error updating repo files: %v", err)
-	        }
+		err = c.UpdateRepoFiles(orgName, name, readmeContent, opts.Jenkinsfile)
+		if err != nil {
+			return nil, fmt.Errorf("error updating repo files: %v", err)
+		}
 
-		statusCheck := "ci/jenkins/build-status"
-		err = c.WaitForStatusCheck(orgName, name, "main", statusCheck)
+		err = c.WaitForStatusCheck(orgName, name, "main", JenkinsStatusCheck)
 		if err != nil {
-			return nil, fmt.Errorf("error waiting for status check '%s', %v", statusCheck, err)
+			return nil, fmt.Errorf("error waiting for status check '%s', %v", JenkinsStatusCheck, err)
 		}
 	} else {
-	        err = c.UpdateRepoFiles(orgName, name, readmeContent, enablePipeline)
-	        if err != nil {
-		        return nil, fmt.Errorf("error updating repo files: %v", err)
-	        }
-        }
+		err = c.UpdateRepoFiles(orgName, name, readmeContent, opts.Jenkinsfile)
+		if err != nil {
+			return nil, fmt.Errorf("error updating repo files: %v", err)
+		}
+	}
 
-	err = c.AddBranchProtection(orgName, name)
-	if err != nil {
+	if err := c.ProtectMainBranch(opts); err != nil {
 		return nil, err
 	}
 
@@ -106,13 +135,47 @@ For more information on creating these workshops, visit [FortinetCloudCSE User R
 
 }
 
-func (c *Client) GenerateRepoFromTemplate(templateOwner, templateRepo, newRepoName string, private bool) (*github.Repository, error) {
+// ProtectMainBranch applies opts.Protection to the main branch of the repo,
+// as classic branch protection or as a repo ruleset, and adds the repo to the
+// org rulesets protecting the repos generated from its template, if any.
+func (c *Client) ProtectMainBranch(opts CreateRepoOptions) error {
+	var err error
+	if opts.UseRulesets {
+		err = c.ApplyRepoRuleset(opts.Org, opts.Name, RulesetOptions{Branch: "main", Profile: opts.Protection, ProtectTags: opts.ProtectTags})
+	} else {
+		err = c.AddBranchProtection(opts.Org, opts.Name, "main", opts.Protection)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = c.AddRepoToOrgRulesets(opts.Org, opts.OrgRulesetName(), opts.Name)
+	return err
+}
+
+// ReadmeContent returns the README committed to a new repo, linking to its
+// GitHub Pages site and to the Pages site of the template repo.
+func ReadmeContent(name string, pagesURL string, templateOwner string, templateRepo string) string {
+	templatePagesURL := fmt.Sprintf("https://%s.github.io/%s/", strings.ToLower(templateOwner), templateRepo)
+
+	return fmt.Sprintf(`
+# %s
+
+To view the workshop, please go here: [GitHub Pages Link](%s)
+
+---
+
+For more information on creating these workshops, visit [%s %s](%s)
+`, name, pagesURL, templateOwner, templateRepo, templatePagesURL)
+}
+
+func (c *Client) GenerateRepoFromTemplate(templateOwner, templateRepo, owner, newRepoName string, private bool) (*github.Repository, error) {
 	ctx := context.Background()
 
 	payload := map[string]interface{}{
 		"name":        newRepoName,
 		"private":     private,
-		"owner":       templateOwner,
+		"owner":       owner,
 		"description": "This repo was generated from " + templateRepo,
 	}
 
@@ -131,10 +194,10 @@ func (c *Client) GenerateRepoFromTemplate(templateOwner, templateRepo, newRepoNa
 	return &repo, nil
 }
 
-func (c *Client) DeleteRepo(templateOwner string, repoName string) error {
+func (c *Client) DeleteRepo(orgName string, repoName string) error {
 	ctx := context.Background()
 
-	apiPath := fmt.Sprintf("repos/%s/%s", templateOwner, repoName)
+	apiPath := fmt.Sprintf("repos/%s/%s", orgName, repoName)
 	req, err := c.client.NewRequest("DELETE", apiPath, nil)
 	if err != nil {
 		return fmt.Errorf("error deleting repo %v", err)
@@ -166,31 +229,6 @@ func (c *Client) WaitForMainBranch(orgName string, repoName string) error {
 	return errors.New("wait for main branch timed out after multiple attempts")
 }
 
-func (c *Client) AddBranchProtection(orgName string, repoName string) error {
-	ctx := context.Background()
-
-	protectionRequest := &github.ProtectionRequest{
-		RequiredStatusChecks: &github.RequiredStatusChecks{
-			Strict:   true,
-			Contexts: &[]string{"ci/jenkins/build-status"},
-		},
-		EnforceAdmins: false,
-		Restrictions:  nil,
-		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcementRequest{
-			DismissStaleReviews:          false,
-			RequireCodeOwnerReviews:      false,
-			RequiredApprovingReviewCount: 0,
-		},
-	}
-
-	_, _, err := c.client.Repositories.UpdateBranchProtection(ctx, orgName, repoName, "main", protectionRequest)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func (c *Client) EnableGitHubPages(orgName string, repoName string) (string, error) {
 	ctx := context.Background()
 	opts := &github.Pages{
@@ -205,25 +243,10 @@ func (c *Client) EnableGitHubPages(orgName string, repoName string) (string, err
 		return "", fmt.Errorf("error enabling GitHub Pages: %v", err)
 	}
 
-	apiURL := fmt.Sprintf("repos/%s/%s/pages", orgName, repoName)
-	req, err := c.client.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return "", fmt.Errorf("error creating request to fetch pages URL: %v", err)
-	}
-
-	var pagesResponse struct {
-		HTMLURL string `json:"html_url"`
-	}
-
-	_, err = c.client.Do(ctx, req, &pagesResponse)
-	if err != nil {
-		return "", fmt.Errorf("error fetching GitHub Pages URL: %v", err)
-	}
-
-	return pagesResponse.HTMLURL, nil
+	return c.PagesURL(orgName, repoName)
 }
 
-func (c *Client) UpdateRepoFiles(orgName string, repoName string, readmeContent string, enablePipeline bool) error {
+func (c *Client) UpdateRepoFiles(orgName string, repoName string, readmeContent string, jenkinsfile string) error {
 	ctx := context.Background()
 
 	// Get the latest commit and tree SHA from the main branch
@@ -244,44 +267,8 @@ func (c *Client) UpdateRepoFiles(orgName string, repoName string, readmeContent
 	}
 
 	// Enable Jenkins
-	if enablePipeline {
-		jenkinsConfigData, err := os.ReadFile("github/Jenkinsfile")
-		if err != nil {
-			return fmt.Errorf("error reading Jenkinsfile in UpdateRepoFiles function call")
-		}
-		content := string(jenkinsConfigData)
-
-		// Define which "when" expressions to replace: 1-based index (e.g., []int{2} to replace only the second one)
-		indicesToReplace := map[int]bool{
-			2: true, // only replace the 2nd instance
-		}
-
-		// Match all occurrences of the when-expression block
-		re := regexp.MustCompile(`when\s*\{\s*expression\s*\{\s*false\s*\}\s*\}`)
-		matches := re.FindAllStringIndex(content, -1)
-
-		if len(matches) == 0 {
-			fmt.Println("No 'when { expression { false } }' blocks found.")
-			files["Jenkinsfile"] = content
-			return nil
-		}
-
-		// Replace only the specified indices
-		var updatedContent string
-		lastIndex := 0
-		for i, match := range matches {
-			start, end := match[0], match[1]
-			updatedContent += content[lastIndex:start]
-			if indicesToReplace[i+1] { // 1-based index
-				updatedContent += "when { expression { true } }"
-			} else {
-				updatedContent += content[start:end]
-			}
-			lastIndex = end
-		}
-		updatedContent += content[lastIndex:] // add the rest
-
-		files["Jenkinsfile"] = updatedContent
+	if jenkinsfile != "" {
+		files["Jenkinsfile"] = jenkinsfile
 	}
 
 	var treeEntries []*github.TreeEntry
@@ -334,32 +321,6 @@ func (c *Client) UpdateRepoFiles(orgName string, repoName string, readmeContent
 	return nil
 }
 
-func (c *Client) CreateWebhook(orgName string, repoName string, webhookURL string) error {
-
-	ctx := context.Background()
-
-	webhookConfig := &github.HookConfig{
-		URL:         github.String(webhookURL),
-		ContentType: github.String("json"),
-	}
-
-	hook := &github.Hook{
-		Name:   github.String("web"),
-		Active: github.Bool(true),
-		Events: []string{"push"},
-		Config: webhookConfig,
-	}
-
-	_, _, err := c.client.Repositories.CreateHook(ctx, orgName, repoName, hook)
-	if err != nil {
-		return fmt.Errorf("error creating webhook for repository '%s': %v", repoName, err)
-	}
-
-	fmt.Printf("Webhook created successfully for repository '%s' with URL '%s'\n", repoName, webhookURL)
-	return nil
-
-}
-
 func (c *Client) WaitForStatusCheck(orgName, repoName, branch, statusCheck string) error {
 	ctx := context.Background()
 
@@ -386,20 +347,38 @@ func (c *Client) WaitForStatusCheck(orgName, repoName, branch, statusCheck strin
 	return fmt.Errorf("status check '%s' not reported after multiple attempts", statusCheck)
 }
 
-func (c *Client) AddCollaborators(owner, repo string, collaborators []string, permission string) error {
+// CollaboratorResult reports what AddCollaborators did for each user.
+type CollaboratorResult struct {
+	Added   []string // have access now
+	Invited []string // were sent an invitation they still have to accept
+}
+
+// AddCollaborators gives users a permission on a repo. GitHub adds org
+// members right away but only invites other users, so the result tells the
+// two apart.
+func (c *Client) AddCollaborators(owner, repo string, collaborators []string, permission string) (CollaboratorResult, error) {
 	ctx := context.Background()
 
 	collabOpts := &github.RepositoryAddCollaboratorOptions{
 		Permission: permission,
 	}
 
+	var result CollaboratorResult
 	for _, collaborator := range collaborators {
-		_, _, err := c.client.Repositories.AddCollaborator(ctx, owner, repo, collaborator, collabOpts)
+		_, resp, err := c.client.Repositories.AddCollaborator(ctx, owner, repo, collaborator, collabOpts)
 		if err != nil {
 			log.Printf("Failed to add collaborator %s: %v", collaborator, err)
-			return err
+			return result, err
 		}
+		// GitHub answers 201 with an invitation, or 204 if the user was
+		// added directly; go-github returns a non-nil invitation either way.
+		if resp.StatusCode == http.StatusCreated {
+			result.Invited = append(result.Invited, collaborator)
+			fmt.Printf("Invited %s to %s/%s with %s permission; access starts once they accept\n", collaborator, owner, repo, permission)
+			continue
+		}
+		result.Added = append(result.Added, collaborator)
 		fmt.Printf("Successfully added %s to %s/%s with %s permission\n", collaborator, owner, repo, permission)
 	}
-	return nil
+	return result, nil
 }
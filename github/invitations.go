@@ -0,0 +1,66 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// Invitation is a pending invitation to collaborate on a repo.
+type Invitation struct {
+	ID         int64
+	Repo       string
+	Login      string
+	Permission string // one of RepoPermissions
+	Inviter    string
+	CreatedAt  time.Time
+	Expired    bool
+}
+
+// ListInvitations returns a repo's pending invitations.
+func (c *Client) ListInvitations(owner string, repo string) ([]*Invitation, error) {
+	invitations, err := c.listInvitations(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []*Invitation
+	for _, inv := range invitations {
+		all = append(all, &Invitation{
+			ID:         inv.GetID(),
+			Repo:       repo,
+			Login:      inv.GetInvitee().GetLogin(),
+			Permission: normalizePermission(inv.GetPermissions()),
+			Inviter:    inv.GetInviter().GetLogin(),
+			CreatedAt:  inv.GetCreatedAt().Time,
+			Expired:    inv.GetExpired(),
+		})
+	}
+	return all, nil
+}
+
+// CancelInvitation deletes a pending invitation.
+func (c *Client) CancelInvitation(owner string, inv *Invitation) error {
+	_, err := c.client.Repositories.DeleteInvitation(context.Background(), owner, inv.Repo, inv.ID)
+	if err != nil {
+		return fmt.Errorf("error cancelling invitation of %s to repository '%s': %v", inv.Login, inv.Repo, err)
+	}
+	return nil
+}
+
+// ResendInvitation replaces an invitation with a new one for the same user
+// and permission, which restarts its expiry and sends a fresh email.
+func (c *Client) ResendInvitation(owner string, inv *Invitation) error {
+	if err := c.CancelInvitation(owner, inv); err != nil {
+		return err
+	}
+
+	_, _, err := c.client.Repositories.AddCollaborator(context.Background(), owner, inv.Repo, inv.Login,
+		&github.RepositoryAddCollaboratorOptions{Permission: inv.Permission})
+	if err != nil {
+		return fmt.Errorf("error re-inviting %s to repository '%s' after cancelling the old invitation: %v", inv.Login, inv.Repo, err)
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package github
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// DefaultJenkinsfile is the Jenkinsfile committed to new projects unless
+// another one is given.
+//
+//go:embed Jenkinsfile
+var DefaultJenkinsfile string
+
+// LoadJenkinsfile reads the Jenkinsfile at path, or returns the embedded
+// default if path is empty.
+func LoadJenkinsfile(path string) (string, error) {
+	if path == "" {
+		return DefaultJenkinsfile, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading Jenkinsfile: %v", err)
+	}
+	return string(data), nil
+}
+
+// Stage is a declarative stage in a Jenkinsfile. A stage is disabled when its
+// top-level when block is "when { expression { false } }".
+type Stage struct {
+	Name    string
+	Enabled bool
+
+	bodyStart int // offset just after the stage's opening brace
+	whenStart int // offset of the toggle when block, or -1
+	whenEnd   int
+}
+
+var (
+	stagePattern      = regexp.MustCompile(`stage\s*\(\s*(?:'([^']*)'|"([^"]*)")\s*\)\s*\{`)
+	toggleWhenPattern = regexp.MustCompile(`when\s*\{\s*expression\s*\{\s*(true|false)\s*\}\s*\}`)
+	anyWhenPattern    = regexp.MustCompile(`when\s*\{`)
+)
+
+// codeMask marks the bytes of a Jenkinsfile that are Groovy code rather than
+// string literals or comments, so braces and keywords inside strings (such as
+// shell scripts) are ignored.
+func codeMask(content string) []bool {
+	mask := make([]bool, len(content))
+	for i := 0; i < len(content); {
+		rest := content[i:]
+		var end int
+
+		switch {
+		case strings.HasPrefix(rest, "//"):
+			end = strings.IndexByte(rest, '\n')
+		case strings.HasPrefix(rest, "/*"):
+			if end = strings.Index(rest[2:], "*/"); end >= 0 {
+				end += 4
+			}
+		case strings.HasPrefix(rest, "'''"), strings.HasPrefix(rest, `"""`):
+			if end = strings.Index(rest[3:], rest[:3]); end >= 0 {
+				end += 6
+			}
+		case rest[0] == '\'' || rest[0] == '"':
+			end = -1
+			for j := 1; j < len(rest); j++ {
+				if rest[j] == '\\' {
+					j++
+				} else if rest[j] == rest[0] {
+					end = j + 1
+					break
+				}
+			}
+		default:
+			mask[i] = true
+			i++
+			continue
+		}
+
+		if end < 0 {
+			end = len(rest)
+		}
+		i += end
+	}
+	return mask
+}
+
+// matchingBrace returns the offset of the brace closing the one before start,
+// or -1 if it is unbalanced.
+func matchingBrace(content string, mask []bool, start int) int {
+	depth := 1
+	for i := start; i < len(content); i++ {
+		if !mask[i] {
+			continue
+		}
+		switch content[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// braceDepth returns how deeply offset pos is nested in braces opened at or
+// after start.
+func braceDepth(content string, mask []bool, start int, pos int) int {
+	depth := 0
+	for i := start; i < pos; i++ {
+		if !mask[i] {
+			continue
+		}
+		switch content[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		}
+	}
+	return depth
+}
+
+// ParseStages returns the stages of a declarative Jenkinsfile in order.
+func ParseStages(content string) ([]Stage, error) {
+	mask := codeMask(content)
+
+	var stages []Stage
+	for _, match := range stagePattern.FindAllStringSubmatchIndex(content, -1) {
+		if !mask[match[0]] {
+			continue
+		}
+
+		name := ""
+		if match[2] >= 0 {
+			name = content[match[2]:match[3]]
+		} else {
+			name = content[match[4]:match[5]]
+		}
+
+		bodyStart := match[1]
+		bodyEnd := matchingBrace(content, mask, bodyStart)
+		if bodyEnd < 0 {
+			return nil, fmt.Errorf("stage '%s' has no closing brace", name)
+		}
+		body := content[bodyStart:bodyEnd]
+
+		stage := Stage{Name: name, Enabled: true, bodyStart: bodyStart, whenStart: -1}
+		for _, when := range anyWhenPattern.FindAllStringIndex(body, -1) {
+			pos := bodyStart + when[0]
+			if !mask[pos] || braceDepth(content, mask, bodyStart, pos) != 0 {
+				continue
+			}
+
+			toggle := toggleWhenPattern.FindStringSubmatchIndex(body[when[0]:])
+			if toggle == nil || toggle[0] != 0 {
+				// A custom condition; the stage can't be toggled.
+				stage.whenStart, stage.whenEnd = pos, -1
+				break
+			}
+			stage.whenStart = pos
+			stage.whenEnd = pos + toggle[1]
+			stage.Enabled = body[when[0]+toggle[2]:when[0]+toggle[3]] == "true"
+			break
+		}
+
+		stages = append(stages, stage)
+	}
+	return stages, nil
+}
+
+// SetStages enables and disables stages by name, returning the updated
+// Jenkinsfile. Enabling a stage sets its when expression to true; disabling
+// one sets it to false, adding the when block if needed. Stages with another
+// kind of when condition can't be toggled.
+func SetStages(content string, enable []string, disable []string) (string, error) {
+	stages, err := ParseStages(content)
+	if err != nil {
+		return "", err
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range enable {
+		wanted[name] = true
+	}
+	for _, name := range disable {
+		if wanted[name] {
+			return "", fmt.Errorf("stage '%s' is both enabled and disabled", name)
+		}
+		wanted[name] = false
+	}
+
+	var names []string
+	found := map[string]bool{}
+	for _, stage := range stages {
+		names = append(names, stage.Name)
+		found[stage.Name] = true
+	}
+	for name := range wanted {
+		if !found[name] {
+			return "", fmt.Errorf("stage '%s' not found in Jenkinsfile; stages are: '%s'", name, strings.Join(names, "', '"))
+		}
+	}
+
+	// Edit from the end so earlier offsets stay valid.
+	for i := len(stages) - 1; i >= 0; i-- {
+		stage := stages[i]
+		enabled, ok := wanted[stage.Name]
+		if !ok {
+			continue
+		}
+
+		if stage.whenStart >= 0 && stage.whenEnd < 0 {
+			return "", fmt.Errorf("stage '%s' has its own when condition and can't be toggled", stage.Name)
+		}
+
+		when := fmt.Sprintf("when { expression { %t } }", enabled)
+		switch {
+		case stage.whenStart >= 0:
+			content = content[:stage.whenStart] + when + content[stage.whenEnd:]
+		case !enabled:
+			content = content[:stage.bodyStart] + "\n" + stageIndent(content, stage.bodyStart) + when + content[stage.bodyStart:]
+		}
+	}
+	return content, nil
+}
+
+// stageIndent returns the indentation for a new first line in the stage body
+// opened at bodyStart: that of the following line, if any.
+func stageIndent(content string, bodyStart int) string {
+	rest := content[bodyStart:]
+	if nl := strings.IndexByte(rest, '\n'); nl >= 0 {
+		line := rest[nl+1:]
+		return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	}
+	return "    "
+}
+
+// PrepareJenkinsfile applies the create-project stage toggles to a
+// Jenkinsfile template, returning the content to commit.
+func PrepareJenkinsfile(content string, enable []string, disable []string) (string, error) {
+	if len(enable) == 0 && len(disable) == 0 {
+		return content, nil
+	}
+	return SetStages(content, enable, disable)
+}
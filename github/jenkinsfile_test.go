@@ -0,0 +1,311 @@
+package github
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const simpleJenkinsfile = `pipeline {
+    agent any
+    stages {
+        stage('Build') {
+            steps {
+                sh 'make'
+            }
+        }
+        stage("Deploy") {
+            when { expression { false } }
+            steps {
+                sh 'make deploy'
+            }
+        }
+    }
+}
+`
+
+const nestedJenkinsfile = `pipeline {
+    agent any
+    stages {
+        stage('Checks') {
+            parallel {
+                stage('Lint') {
+                    when { expression { false } }
+                    steps {
+                        sh 'make lint'
+                    }
+                }
+                stage('Test') {
+                    steps {
+                        sh 'make test'
+                    }
+                }
+            }
+        }
+    }
+}
+`
+
+// Braces and stage calls inside strings and comments must be ignored.
+const trickyJenkinsfile = `pipeline {
+    agent any
+    stages {
+        // stage('Commented') { when { expression { false } } }
+        /* stage('Block') {
+        } */
+        stage('Build') {
+            steps {
+                sh 'echo "}" && echo "stage(\'Fake\') {"'
+                sh """
+                    if [ -f x ]; then { echo '}'; }; fi
+                """
+                echo "when { expression { false } }"
+            }
+        }
+    }
+}
+`
+
+const customWhenJenkinsfile = `pipeline {
+    agent any
+    stages {
+        stage('Release') {
+            when { branch 'main' }
+            steps {
+                sh 'make release'
+            }
+        }
+    }
+}
+`
+
+type stageState struct {
+	Name    string
+	Enabled bool
+}
+
+func stageStates(t *testing.T, content string) []stageState {
+	t.Helper()
+	stages, err := ParseStages(content)
+	if err != nil {
+		t.Fatalf("ParseStages: %v", err)
+	}
+	var states []stageState
+	for _, s := range stages {
+		states = append(states, stageState{s.Name, s.Enabled})
+	}
+	return states
+}
+
+func TestParseStages(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []stageState
+		wantErr string
+	}{
+		{
+			name:    "simple",
+			content: simpleJenkinsfile,
+			want:    []stageState{{"Build", true}, {"Deploy", false}},
+		},
+		{
+			name:    "nested stages",
+			content: nestedJenkinsfile,
+			want:    []stageState{{"Checks", true}, {"Lint", false}, {"Test", true}},
+		},
+		{
+			name:    "braces in strings and comments",
+			content: trickyJenkinsfile,
+			want:    []stageState{{"Build", true}},
+		},
+		{
+			name:    "custom when",
+			content: customWhenJenkinsfile,
+			want:    []stageState{{"Release", true}},
+		},
+		{
+			name:    "when enabled",
+			content: strings.Replace(simpleJenkinsfile, "{ false }", "{ true }", 1),
+			want:    []stageState{{"Build", true}, {"Deploy", true}},
+		},
+		{
+			name:    "unclosed stage",
+			content: "pipeline { stages { stage('Build') { steps { sh 'make' }",
+			wantErr: "stage 'Build' has no closing brace",
+		},
+		{
+			name:    "no stages",
+			content: "pipeline { agent any }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stages, err := ParseStages(tt.content)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseStages error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseStages: %v", err)
+			}
+
+			var got []stageState
+			for _, s := range stages {
+				got = append(got, stageState{s.Name, s.Enabled})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseStages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetStages(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		enable  []string
+		disable []string
+		want    []stageState
+		wantErr string
+	}{
+		{
+			name:    "disable adds when",
+			content: simpleJenkinsfile,
+			disable: []string{"Build"},
+			want:    []stageState{{"Build", false}, {"Deploy", false}},
+		},
+		{
+			name:    "enable existing when",
+			content: simpleJenkinsfile,
+			enable:  []string{"Deploy"},
+			want:    []stageState{{"Build", true}, {"Deploy", true}},
+		},
+		{
+			name:    "enable without when is a no-op",
+			content: simpleJenkinsfile,
+			enable:  []string{"Build"},
+			want:    []stageState{{"Build", true}, {"Deploy", false}},
+		},
+		{
+			name:    "nested stages",
+			content: nestedJenkinsfile,
+			enable:  []string{"Lint"},
+			disable: []string{"Test"},
+			want:    []stageState{{"Checks", true}, {"Lint", true}, {"Test", false}},
+		},
+		{
+			name:    "disable outer stage leaves nested when alone",
+			content: nestedJenkinsfile,
+			disable: []string{"Checks"},
+			want:    []stageState{{"Checks", false}, {"Lint", false}, {"Test", true}},
+		},
+		{
+			name:    "braces in strings and comments",
+			content: trickyJenkinsfile,
+			disable: []string{"Build"},
+			want:    []stageState{{"Build", false}},
+		},
+		{
+			name:    "unknown stage",
+			content: simpleJenkinsfile,
+			enable:  []string{"Publish"},
+			wantErr: "stage 'Publish' not found in Jenkinsfile; stages are: 'Build', 'Deploy'",
+		},
+		{
+			name:    "custom when",
+			content: customWhenJenkinsfile,
+			disable: []string{"Release"},
+			wantErr: "stage 'Release' has its own when condition",
+		},
+		{
+			name:    "enabled and disabled",
+			content: simpleJenkinsfile,
+			enable:  []string{"Build"},
+			disable: []string{"Build"},
+			wantErr: "stage 'Build' is both enabled and disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetStages(tt.content, tt.enable, tt.disable)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SetStages error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetStages: %v", err)
+			}
+
+			if states := stageStates(t, got); !reflect.DeepEqual(states, tt.want) {
+				t.Errorf("stages after SetStages = %v, want %v\n%s", states, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSetStagesIndentsNewWhen(t *testing.T) {
+	got, err := SetStages(simpleJenkinsfile, nil, []string{"Build"})
+	if err != nil {
+		t.Fatalf("SetStages: %v", err)
+	}
+
+	want := "        stage('Build') {\n            when { expression { false } }\n            steps {"
+	if !strings.Contains(got, want) {
+		t.Errorf("SetStages did not insert an indented when block:\n%s", got)
+	}
+}
+
+func TestPrepareJenkinsfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		enable  []string
+		disable []string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "no toggles returns content unchanged",
+			content: "not { a jenkinsfile",
+			want:    "not { a jenkinsfile",
+		},
+		{
+			name:    "toggles",
+			content: simpleJenkinsfile,
+			enable:  []string{"Deploy"},
+			want:    strings.Replace(simpleJenkinsfile, "{ false }", "{ true }", 1),
+		},
+		{
+			name:    "unknown stage",
+			content: simpleJenkinsfile,
+			disable: []string{"Publish"},
+			wantErr: "stage 'Publish' not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PrepareJenkinsfile(tt.content, tt.enable, tt.disable)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("PrepareJenkinsfile error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PrepareJenkinsfile: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("PrepareJenkinsfile =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// ProtectionProfile is a named branch protection policy. Profiles can be
+// defined in a JSON file mapping profile names to these fields.
+//
+// Pull requests are required and force pushes and deletion blocked unless
+// the profile opts out, so that zero values keep a branch protected.
+type ProtectionProfile struct {
+	RequiredChecks       []string `json:"required_checks"`        // status check contexts that must pass
+	StrictChecks         bool     `json:"strict_checks"`          // branches must be up to date before merging
+	PullRequestsOptional bool     `json:"pull_requests_optional"` // allow pushing without a pull request; the review fields are ignored
+	RequiredReviews      int      `json:"required_reviews"`       // approving reviews needed to merge
+	RequireCodeOwners    bool     `json:"require_code_owners"`    // code owners must approve changes to their files
+	DismissStaleReviews  bool     `json:"dismiss_stale_reviews"`  // new commits dismiss earlier approvals
+	EnforceAdmins        bool     `json:"enforce_admins"`         // apply the rules to admins too
+	LinearHistory        bool     `json:"linear_history"`         // reject merge commits
+	AllowForcePushes     bool     `json:"allow_force_pushes"`     // allow rewriting the branch's history
+	AllowDeletions       bool     `json:"allow_deletions"`        // allow deleting the branch
+	PushUsers            []string `json:"push_users"`             // if set with PushTeams, only these may push
+	PushTeams            []string `json:"push_teams"`
+}
+
+// DefaultProtectionProfile is the profile applied when none is selected.
+const DefaultProtectionProfile = "default"
+
+// BuiltinProtectionProfiles are available without a config file. A config
+// file can override them by name.
+var BuiltinProtectionProfiles = map[string]ProtectionProfile{
+	DefaultProtectionProfile: {
+		RequiredChecks: []string{"ci/jenkins/build-status"},
+		StrictChecks:   true,
+	},
+	"customer-facing": {
+		RequiredChecks:      []string{"ci/jenkins/build-status"},
+		StrictChecks:        true,
+		RequiredReviews:     1,
+		RequireCodeOwners:   true,
+		DismissStaleReviews: true,
+	},
+}
+
+// LoadProtectionProfiles returns the built-in profiles merged with those in
+// the JSON file at path, if path is not empty.
+func LoadProtectionProfiles(path string) (map[string]ProtectionProfile, error) {
+	profiles := make(map[string]ProtectionProfile, len(BuiltinProtectionProfiles))
+	for name, profile := range BuiltinProtectionProfiles {
+		profiles[name] = profile
+	}
+	if path == "" {
+		return profiles, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading protection profiles: %v", err)
+	}
+
+	var fileProfiles map[string]ProtectionProfile
+	if err := json.Unmarshal(data, &fileProfiles); err != nil {
+		return nil, fmt.Errorf("error parsing protection profiles in %s: %v", path, err)
+	}
+	for name, profile := range fileProfiles {
+		profiles[name] = profile
+	}
+	return profiles, nil
+}
+
+// LookupProtectionProfile returns the named profile from profiles.
+func LookupProtectionProfile(profiles map[string]ProtectionProfile, name string) (ProtectionProfile, error) {
+	profile, ok := profiles[name]
+	if !ok {
+		var names []string
+		for n := range profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return ProtectionProfile{}, fmt.Errorf("unknown protection profile '%s' (available: %v)", name, names)
+	}
+	return profile, nil
+}
+
+// request converts the profile to a classic branch protection request.
+func (p ProtectionProfile) request() *github.ProtectionRequest {
+	req := &github.ProtectionRequest{
+		EnforceAdmins:        p.EnforceAdmins,
+		RequireLinearHistory: github.Bool(p.LinearHistory),
+		AllowForcePushes:     github.Bool(p.AllowForcePushes),
+		AllowDeletions:       github.Bool(p.AllowDeletions),
+	}
+
+	if !p.PullRequestsOptional {
+		req.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          p.DismissStaleReviews,
+			RequireCodeOwnerReviews:      p.RequireCodeOwners,
+			RequiredApprovingReviewCount: p.RequiredReviews,
+		}
+	}
+
+	if len(p.RequiredChecks) > 0 {
+		checks := append([]string{}, p.RequiredChecks...)
+		req.RequiredStatusChecks = &github.RequiredStatusChecks{
+			Strict:   p.StrictChecks,
+			Contexts: &checks,
+		}
+	}
+
+	if len(p.PushUsers) > 0 || len(p.PushTeams) > 0 {
+		req.Restrictions = &github.BranchRestrictionsRequest{
+			Users: append([]string{}, p.PushUsers...),
+			Teams: append([]string{}, p.PushTeams...),
+		}
+	}
+	return req
+}
+
+// AddBranchProtection applies a protection profile to a branch using classic
+// branch protection.
+func (c *Client) AddBranchProtection(orgName string, repoName string, branch string, profile ProtectionProfile) error {
+	ctx := context.Background()
+
+	_, _, err := c.client.Repositories.UpdateBranchProtection(ctx, orgName, repoName, branch, profile.request())
+	if err != nil {
+		return fmt.Errorf("error protecting branch '%s' in repository '%s': %v", branch, repoName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,414 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// Bypass actor IDs GitHub uses for built-in roles.
+const (
+	orgAdminActorID      = 1
+	repoAdminRoleActorID = 5
+)
+
+// RulesetOptions describes the rulesets to apply for a protection profile.
+type RulesetOptions struct {
+	Branch  string
+	Profile ProtectionProfile
+
+	// ProtectTags adds a second ruleset that stops tags from being moved or
+	// deleted.
+	ProtectTags bool
+}
+
+func branchRulesetName(branch string) string {
+	return fmt.Sprintf("%s branch protection", branch)
+}
+
+const tagRulesetName = "tag protection"
+
+// rulesets converts the options to the rulesets to apply in an org. Pushes
+// restricted to teams become an update rule the teams can bypass; rulesets
+// can't grant bypass to individual users.
+func (c *Client) rulesets(orgName string, opts RulesetOptions) ([]*github.Ruleset, error) {
+	ctx := context.Background()
+	p := opts.Profile
+
+	if len(p.PushUsers) > 0 {
+		return nil, errors.New("push restrictions for individual users can't be expressed as a ruleset; use push teams instead")
+	}
+
+	var bypass []*github.BypassActor
+	if !p.EnforceAdmins {
+		bypass = append(bypass,
+			&github.BypassActor{ActorID: github.Int64(orgAdminActorID), ActorType: github.String("OrganizationAdmin"), BypassMode: github.String("always")},
+			&github.BypassActor{ActorID: github.Int64(repoAdminRoleActorID), ActorType: github.String("RepositoryRole"), BypassMode: github.String("always")},
+		)
+	}
+
+	var rules []*github.RepositoryRule
+	if !p.AllowDeletions {
+		rules = append(rules, github.NewDeletionRule())
+	}
+	if !p.AllowForcePushes {
+		rules = append(rules, github.NewNonFastForwardRule())
+	}
+	if !p.PullRequestsOptional {
+		rules = append(rules, github.NewPullRequestRule(&github.PullRequestRuleParameters{
+			DismissStaleReviewsOnPush:    p.DismissStaleReviews,
+			RequireCodeOwnerReview:       p.RequireCodeOwners,
+			RequiredApprovingReviewCount: p.RequiredReviews,
+		}))
+	}
+
+	if len(p.RequiredChecks) > 0 {
+		var checks []github.RuleRequiredStatusChecks
+		for _, check := range p.RequiredChecks {
+			checks = append(checks, github.RuleRequiredStatusChecks{Context: check})
+		}
+		rules = append(rules, github.NewRequiredStatusChecksRule(&github.RequiredStatusChecksRuleParameters{
+			RequiredStatusChecks:             checks,
+			StrictRequiredStatusChecksPolicy: p.StrictChecks,
+		}))
+	}
+
+	if p.LinearHistory {
+		rules = append(rules, github.NewRequiredLinearHistoryRule())
+	}
+
+	branchBypass := bypass
+	if len(p.PushTeams) > 0 {
+		rules = append(rules, github.NewUpdateRule(nil))
+		for _, slug := range p.PushTeams {
+			team, _, err := c.client.Teams.GetTeamBySlug(ctx, orgName, slug)
+			if err != nil {
+				return nil, fmt.Errorf("error looking up team '%s': %v", slug, err)
+			}
+			branchBypass = append(branchBypass, &github.BypassActor{
+				ActorID: team.ID, ActorType: github.String("Team"), BypassMode: github.String("always"),
+			})
+		}
+	}
+
+	rulesets := []*github.Ruleset{{
+		Name:         branchRulesetName(opts.Branch),
+		Target:       github.String("branch"),
+		Enforcement:  "active",
+		BypassActors: branchBypass,
+		Conditions: &github.RulesetConditions{
+			RefName: &github.RulesetRefConditionParameters{
+				Include: []string{"refs/heads/" + opts.Branch},
+				Exclude: []string{},
+			},
+		},
+		Rules: rules,
+	}}
+
+	if opts.ProtectTags {
+		rulesets = append(rulesets, &github.Ruleset{
+			Name:         tagRulesetName,
+			Target:       github.String("tag"),
+			Enforcement:  "active",
+			BypassActors: bypass,
+			Conditions: &github.RulesetConditions{
+				RefName: &github.RulesetRefConditionParameters{Include: []string{"~ALL"}, Exclude: []string{}},
+			},
+			Rules: []*github.RepositoryRule{
+				github.NewDeletionRule(),
+				github.NewNonFastForwardRule(),
+				github.NewUpdateRule(nil),
+			},
+		})
+	}
+	return rulesets, nil
+}
+
+// ApplyRepoRuleset creates the repo rulesets for a protection profile, or
+// updates them if rulesets with the same names already exist.
+func (c *Client) ApplyRepoRuleset(orgName string, repoName string, opts RulesetOptions) error {
+	ctx := context.Background()
+
+	rulesets, err := c.rulesets(orgName, opts)
+	if err != nil {
+		return err
+	}
+
+	existing, _, err := c.client.Repositories.GetAllRulesets(ctx, orgName, repoName, false)
+	if err != nil {
+		return fmt.Errorf("error listing rulesets for repository '%s': %v", repoName, err)
+	}
+
+	for _, rs := range rulesets {
+		if id := findRuleset(existing, rs.Name); id != nil {
+			_, _, err = c.client.Repositories.UpdateRuleset(ctx, orgName, repoName, *id, rs)
+		} else {
+			_, _, err = c.client.Repositories.CreateRuleset(ctx, orgName, repoName, rs)
+		}
+		if err != nil {
+			return fmt.Errorf("error applying ruleset '%s' to repository '%s': %v", rs.Name, repoName, err)
+		}
+		fmt.Printf("Ruleset '%s' applied to repository '%s'.\n", rs.Name, repoName)
+	}
+	return nil
+}
+
+// ApplyOrgRuleset creates or updates org rulesets for a protection profile
+// that target the given repos. The ruleset names are prefixed with name so
+// several org rulesets can coexist.
+func (c *Client) ApplyOrgRuleset(orgName string, name string, repoNames []string, opts RulesetOptions) error {
+	ctx := context.Background()
+
+	if len(repoNames) == 0 {
+		return errors.New("no repositories to target with the org ruleset")
+	}
+
+	rulesets, err := c.rulesets(orgName, opts)
+	if err != nil {
+		return err
+	}
+
+	existing, err := c.listOrgRulesets(orgName)
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range rulesets {
+		rs.Name = fmt.Sprintf("%s: %s", name, rs.Name)
+		rs.Conditions.RepositoryName = &github.RulesetRepositoryNamesConditionParameters{
+			Include: repoNames,
+			Exclude: []string{},
+		}
+
+		if id := findRuleset(existing, rs.Name); id != nil {
+			_, _, err = c.client.Organizations.UpdateOrganizationRuleset(ctx, orgName, *id, rs)
+		} else {
+			_, _, err = c.client.Organizations.CreateOrganizationRuleset(ctx, orgName, rs)
+		}
+		if err != nil {
+			return fmt.Errorf("error applying org ruleset '%s': %v", rs.Name, err)
+		}
+		fmt.Printf("Org ruleset '%s' applied to %d repositories.\n", rs.Name, len(repoNames))
+	}
+	return nil
+}
+
+// TemplateRulesetName names the org rulesets that protect the repos generated
+// from a template repo.
+func TemplateRulesetName(templateOwner string, templateRepo string) string {
+	return fmt.Sprintf("%s/%s", templateOwner, templateRepo)
+}
+
+// AddRepoToOrgRulesets adds a repo to the targets of the org rulesets applied
+// by ApplyOrgRuleset under the given name, and returns how many it updated.
+// Org rulesets list their repos by name, so repos created after the ruleset
+// are only covered once added.
+//
+// Listing org rulesets needs org admin rights that creating repos doesn't, so
+// a failure to list them only prints a warning. Errors are returned only for
+// matching rulesets that can't be updated.
+func (c *Client) AddRepoToOrgRulesets(orgName string, name string, repoName string) (int, error) {
+	ctx := context.Background()
+
+	existing, err := c.listOrgRulesets(orgName)
+	if err != nil {
+		if isForbidden(err) || isNotFound(err) {
+			err = fmt.Errorf("the token needs read access to the org's administration settings: %v", err)
+		}
+		fmt.Printf("Warning: could not check the org rulesets of '%s': %v\n", orgName, err)
+		fmt.Printf("If org rulesets named '%s: ...' exist, add '%s' to their target repositories in the org settings, or re-run protect-branch --org-ruleset.\n", name, repoName)
+		return 0, nil
+	}
+
+	updated := 0
+	for _, summary := range existing {
+		if !strings.HasPrefix(summary.Name, name+": ") {
+			continue
+		}
+
+		// Listed rulesets come without their conditions and rules.
+		rs, _, err := c.client.Organizations.GetOrganizationRuleset(ctx, orgName, summary.GetID())
+		if err != nil {
+			return updated, fmt.Errorf("error fetching org ruleset '%s': %v", summary.Name, err)
+		}
+		if rs.Conditions == nil || rs.Conditions.RepositoryName == nil {
+			continue
+		}
+		targets := rs.Conditions.RepositoryName
+		if containsRepo(targets.Include, repoName) {
+			continue
+		}
+
+		targets.Include = append(targets.Include, repoName)
+		if _, _, err := c.client.Organizations.UpdateOrganizationRuleset(ctx, orgName, rs.GetID(), rs); err != nil {
+			return updated, fmt.Errorf("error adding repository '%s' to org ruleset '%s': %v", repoName, rs.Name, err)
+		}
+		fmt.Printf("Repository '%s' added to org ruleset '%s'.\n", repoName, rs.Name)
+		updated++
+	}
+	return updated, nil
+}
+
+// listOrgRulesets returns every ruleset of an org, without their conditions
+// and rules. go-github doesn't page through them, so the request is built
+// here.
+func (c *Client) listOrgRulesets(orgName string) ([]*github.Ruleset, error) {
+	ctx := context.Background()
+
+	var all []*github.Ruleset
+	for page := 1; page != 0; {
+		apiPath := fmt.Sprintf("orgs/%s/rulesets?per_page=100&page=%d", orgName, page)
+		req, err := c.client.NewRequest("GET", apiPath, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request to list org rulesets: %v", err)
+		}
+
+		var rulesets []*github.Ruleset
+		resp, err := c.client.Do(ctx, req, &rulesets)
+		if err != nil {
+			return nil, fmt.Errorf("error listing rulesets for org '%s': %w", orgName, err)
+		}
+		all = append(all, rulesets...)
+		page = resp.NextPage
+	}
+	return all, nil
+}
+
+func containsRepo(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func findRuleset(rulesets []*github.Ruleset, name string) *int64 {
+	for _, rs := range rulesets {
+		if rs.Name == name {
+			return rs.ID
+		}
+	}
+	return nil
+}
+
+// ReposFromTemplate returns the names of the repos in an org that were
+// generated from the given template repo.
+func (c *Client) ReposFromTemplate(orgName string, templateOwner string, templateRepo string) ([]string, error) {
+	ctx := context.Background()
+
+	repos, err := c.ListOrgRepos(orgName)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, r := range repos {
+		// The template is only included when fetching a single repo.
+		full, _, err := c.client.Repositories.Get(ctx, orgName, r.GetName())
+		if err != nil {
+			return nil, fmt.Errorf("error fetching repository '%s': %v", r.GetName(), err)
+		}
+		template := full.GetTemplateRepository()
+		if template != nil && strings.EqualFold(template.GetOwner().GetLogin(), templateOwner) && strings.EqualFold(template.GetName(), templateRepo) {
+			names = append(names, r.GetName())
+		}
+	}
+	return names, nil
+}
+
+// ListOrgRepos returns every repo in an org.
+func (c *Client) ListOrgRepos(orgName string) ([]*github.Repository, error) {
+	ctx := context.Background()
+
+	var all []*github.Repository
+	opts := &github.RepositoryListByOrgOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		repos, resp, err := c.client.Repositories.ListByOrg(ctx, orgName, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error listing repositories in org '%s': %v", orgName, err)
+		}
+		all = append(all, repos...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ClassicProtectionProfile reads the classic branch protection of a branch
+// as a protection profile.
+func (c *Client) ClassicProtectionProfile(orgName string, repoName string, branch string) (ProtectionProfile, error) {
+	ctx := context.Background()
+
+	protection, _, err := c.client.Repositories.GetBranchProtection(ctx, orgName, repoName, branch)
+	if err != nil {
+		return ProtectionProfile{}, fmt.Errorf("error fetching protection of branch '%s' in repository '%s': %v", branch, repoName, err)
+	}
+
+	var p ProtectionProfile
+	if checks := protection.GetRequiredStatusChecks(); checks != nil {
+		p.StrictChecks = checks.Strict
+		if checks.Contexts != nil {
+			p.RequiredChecks = *checks.Contexts
+		}
+	}
+	if reviews := protection.GetRequiredPullRequestReviews(); reviews != nil {
+		p.RequiredReviews = reviews.RequiredApprovingReviewCount
+		p.RequireCodeOwners = reviews.RequireCodeOwnerReviews
+		p.DismissStaleReviews = reviews.DismissStaleReviews
+	} else {
+		p.PullRequestsOptional = true
+	}
+	if admins := protection.GetEnforceAdmins(); admins != nil {
+		p.EnforceAdmins = admins.Enabled
+	}
+	if linear := protection.GetRequireLinearHistory(); linear != nil {
+		p.LinearHistory = linear.Enabled
+	}
+	if forcePushes := protection.GetAllowForcePushes(); forcePushes != nil {
+		p.AllowForcePushes = forcePushes.Enabled
+	}
+	if deletions := protection.GetAllowDeletions(); deletions != nil {
+		p.AllowDeletions = deletions.Enabled
+	}
+	if restrictions := protection.GetRestrictions(); restrictions != nil {
+		for _, user := range restrictions.Users {
+			p.PushUsers = append(p.PushUsers, user.GetLogin())
+		}
+		for _, team := range restrictions.Teams {
+			p.PushTeams = append(p.PushTeams, team.GetSlug())
+		}
+	}
+	return p, nil
+}
+
+// ConvertToRuleset replaces the classic protection of a branch with an
+// equivalent repo ruleset. The classic protection is removed only after the
+// ruleset is in place, and kept if keepClassic is set.
+func (c *Client) ConvertToRuleset(orgName string, repoName string, branch string, keepClassic bool) error {
+	ctx := context.Background()
+
+	profile, err := c.ClassicProtectionProfile(orgName, repoName, branch)
+	if err != nil {
+		return err
+	}
+
+	if err := c.ApplyRepoRuleset(orgName, repoName, RulesetOptions{Branch: branch, Profile: profile}); err != nil {
+		return err
+	}
+
+	if keepClassic {
+		return nil
+	}
+
+	_, err = c.client.Repositories.RemoveBranchProtection(ctx, orgName, repoName, branch)
+	if err != nil {
+		return fmt.Errorf("error removing classic protection of branch '%s' in repository '%s': %v", branch, repoName, err)
+	}
+	fmt.Printf("Classic protection removed from branch '%s' in repository '%s'.\n", branch, repoName)
+	return nil
+}
@@ -0,0 +1,167 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// RepoPermissions are the permissions a collaborator or team can have on a
+// repo, from least to most access.
+var RepoPermissions = []string{"pull", "triage", "push", "maintain", "admin"}
+
+// Team is an org team, with its permission on a repo when listed for one.
+type Team struct {
+	Slug        string
+	Name        string
+	Description string
+	Permission  string
+}
+
+func newTeam(t *github.Team) *Team {
+	return &Team{Slug: t.GetSlug(), Name: t.GetName(), Description: t.GetDescription(), Permission: t.GetPermission()}
+}
+
+// TeamGrant gives a team a permission on a repo.
+type TeamGrant struct {
+	Slug       string
+	Permission string
+}
+
+// ParseTeamGrants parses "slug:permission" specs. A spec without a permission
+// grants push.
+func ParseTeamGrants(specs []string) ([]TeamGrant, error) {
+	var grants []TeamGrant
+	for _, spec := range specs {
+		slug, permission, found := strings.Cut(spec, ":")
+		permission = normalizePermission(permission)
+		if !found {
+			permission = "push"
+		}
+		if slug == "" {
+			return nil, fmt.Errorf("invalid team %q: expected slug:permission", spec)
+		}
+		if !slices.Contains(RepoPermissions, permission) {
+			return nil, fmt.Errorf("invalid permission %q for team '%s': use one of %s", permission, slug, strings.Join(RepoPermissions, ", "))
+		}
+		grants = append(grants, TeamGrant{Slug: slug, Permission: permission})
+	}
+	return grants, nil
+}
+
+// TeamSlugs returns the slugs of the teams in grants.
+func TeamSlugs(grants []TeamGrant) []string {
+	var slugs []string
+	for _, g := range grants {
+		slugs = append(slugs, g.Slug)
+	}
+	return slugs
+}
+
+// ListTeams returns every team in an org.
+func (c *Client) ListTeams(orgName string) ([]*Team, error) {
+	ctx := context.Background()
+
+	var all []*Team
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		teams, resp, err := c.client.Teams.ListTeams(ctx, orgName, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error listing teams in org '%s': %v", orgName, err)
+		}
+		for _, t := range teams {
+			all = append(all, newTeam(t))
+		}
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ListRepoTeams returns the teams with access to a repo and their permission.
+func (c *Client) ListRepoTeams(orgName string, repoName string) ([]*Team, error) {
+	ctx := context.Background()
+
+	var all []*Team
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		teams, resp, err := c.client.Repositories.ListTeams(ctx, orgName, repoName, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error listing teams of repository '%s': %v", repoName, err)
+		}
+		for _, t := range teams {
+			all = append(all, newTeam(t))
+		}
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ValidateTeams checks that every team slug exists in the org.
+func (c *Client) ValidateTeams(orgName string, slugs []string) error {
+	for _, slug := range slugs {
+		_, _, err := c.client.Teams.GetTeamBySlug(context.Background(), orgName, slug)
+		if isNotFound(err) {
+			return fmt.Errorf("team '%s' does not exist in org '%s'", slug, orgName)
+		}
+		if err != nil {
+			return fmt.Errorf("error looking up team '%s': %v", slug, err)
+		}
+	}
+	return nil
+}
+
+// AddTeams gives teams their permission on a repo, replacing any permission
+// they already have. All teams are validated before any is added.
+func (c *Client) AddTeams(orgName string, repoName string, grants []TeamGrant) error {
+	if err := c.ValidateTeams(orgName, TeamSlugs(grants)); err != nil {
+		return err
+	}
+
+	for _, g := range grants {
+		_, err := c.client.Teams.AddTeamRepoBySlug(context.Background(), orgName, g.Slug, orgName, repoName,
+			&github.TeamAddTeamRepoOptions{Permission: g.Permission})
+		if err != nil {
+			return fmt.Errorf("error adding team '%s' to repository '%s': %v", g.Slug, repoName, err)
+		}
+		fmt.Printf("Successfully added team %s to %s/%s with %s permission\n", g.Slug, orgName, repoName, g.Permission)
+	}
+	return nil
+}
+
+// RemoveTeam revokes a team's access to a repo.
+func (c *Client) RemoveTeam(orgName string, repoName string, slug string) error {
+	_, err := c.client.Teams.RemoveTeamRepoBySlug(context.Background(), orgName, slug, orgName, repoName)
+	if err != nil {
+		return fmt.Errorf("error removing team '%s' from repository '%s': %v", slug, repoName, err)
+	}
+	return nil
+}
+
+// MissingTeamGrants returns the grants whose team doesn't yet have exactly
+// that permission on the repo.
+func (c *Client) MissingTeamGrants(orgName string, repoName string, grants []TeamGrant) ([]TeamGrant, error) {
+	teams, err := c.ListRepoTeams(orgName, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]string)
+	for _, t := range teams {
+		current[t.Slug] = t.Permission
+	}
+
+	var missing []TeamGrant
+	for _, g := range grants {
+		if current[g.Slug] != g.Permission {
+			missing = append(missing, g)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,68 @@
+package github
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTeamGrants(t *testing.T) {
+	tests := []struct {
+		name    string
+		specs   []string
+		want    []TeamGrant
+		wantErr string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:  "default permission",
+			specs: []string{"workshop-staff"},
+			want:  []TeamGrant{{Slug: "workshop-staff", Permission: "push"}},
+		},
+		{
+			name:  "explicit permissions",
+			specs: []string{"admins:admin", "reviewers:triage"},
+			want:  []TeamGrant{{Slug: "admins", Permission: "admin"}, {Slug: "reviewers", Permission: "triage"}},
+		},
+		{
+			name:  "read and write aliases",
+			specs: []string{"readers:read", "writers:write"},
+			want:  []TeamGrant{{Slug: "readers", Permission: "pull"}, {Slug: "writers", Permission: "push"}},
+		},
+		{
+			name:    "missing slug",
+			specs:   []string{":push"},
+			wantErr: `invalid team ":push"`,
+		},
+		{
+			name:    "unknown permission",
+			specs:   []string{"admins:owner"},
+			wantErr: `invalid permission "owner" for team 'admins'`,
+		},
+		{
+			name:    "empty permission",
+			specs:   []string{"admins:"},
+			wantErr: `invalid permission "" for team 'admins'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTeamGrants(tt.specs)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseTeamGrants error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTeamGrants: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTeamGrants = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// WebhookOptions describes a repo webhook. Hooks are matched by URL, so a
+// repo has at most one hook per URL.
+type WebhookOptions struct {
+	URL    string
+	Events []string
+	Active bool
+
+	// Secret signs deliveries. GitHub never returns it, so an empty Secret
+	// leaves an existing hook's secret unchanged.
+	Secret string
+}
+
+// Webhook is a repo webhook as reported by GitHub.
+type Webhook struct {
+	ID     int64
+	URL    string
+	Events []string
+	Active bool
+
+	// LastResponse is the status of the most recent delivery, e.g. "200 OK".
+	LastResponse string
+}
+
+func newWebhook(hook *github.Hook) *Webhook {
+	w := &Webhook{
+		ID:     hook.GetID(),
+		URL:    hook.GetConfig().GetURL(),
+		Events: hook.Events,
+		Active: hook.GetActive(),
+	}
+
+	code, _ := hook.LastResponse["code"].(float64)
+	status, _ := hook.LastResponse["status"].(string)
+	w.LastResponse = status
+	if code != 0 {
+		w.LastResponse = fmt.Sprintf("%.0f %s", code, status)
+	}
+	return w
+}
+
+// Options returns the webhook's current settings, without the secret.
+func (w *Webhook) Options() WebhookOptions {
+	return WebhookOptions{URL: w.URL, Events: w.Events, Active: w.Active}
+}
+
+// WebhookDelivery is one attempt by GitHub to deliver an event to a webhook.
+type WebhookDelivery struct {
+	ID          int64
+	GUID        string
+	DeliveredAt time.Time
+	Event       string
+	Action      string
+	StatusCode  int
+	Status      string
+	Duration    time.Duration
+	Redelivery  bool
+}
+
+func newWebhookDelivery(d *github.HookDelivery) *WebhookDelivery {
+	var duration time.Duration
+	if d.Duration != nil {
+		duration = time.Duration(*d.Duration * float64(time.Second))
+	}
+
+	return &WebhookDelivery{
+		ID:          d.GetID(),
+		GUID:        d.GetGUID(),
+		DeliveredAt: d.GetDeliveredAt().Time,
+		Event:       d.GetEvent(),
+		Action:      d.GetAction(),
+		StatusCode:  d.GetStatusCode(),
+		Status:      d.GetStatus(),
+		Duration:    duration,
+		Redelivery:  d.GetRedelivery(),
+	}
+}
+
+func (o WebhookOptions) hook() *github.Hook {
+	hook := &github.Hook{
+		Name:   github.String("web"),
+		Active: github.Bool(o.Active),
+		Events: o.Events,
+		Config: &github.HookConfig{
+			URL:         github.String(o.URL),
+			ContentType: github.String("json"),
+		},
+	}
+	if o.Secret != "" {
+		hook.Config.Secret = github.String(o.Secret)
+	}
+	return hook
+}
+
+// GenerateWebhookSecret returns a random secret for signing webhook deliveries.
+func GenerateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating webhook secret: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// JenkinsWebhookURL returns the URL of the Jenkins GitHub plugin's webhook
+// endpoint.
+func (c *Client) JenkinsWebhookURL() string {
+	return strings.TrimSuffix(c.JenkinsUrl, "/") + "/github-webhook/"
+}
+
+// IsJenkinsWebhook reports whether webhookURL is the Jenkins webhook endpoint.
+func (c *Client) IsJenkinsWebhook(webhookURL string) bool {
+	return sameURL(webhookURL, c.JenkinsWebhookURL())
+}
+
+// CreateWebhook adds a push hook pointing at webhookURL, signed with secret
+// unless it is empty. If the repo already has a hook with that URL it is
+// activated and subscribed to push instead of adding a duplicate.
+func (c *Client) CreateWebhook(orgName string, repoName string, webhookURL string, secret string) (*Webhook, error) {
+	existing, err := c.FindWebhook(orgName, repoName, webhookURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		hook, err := c.AddWebhook(orgName, repoName, WebhookOptions{URL: webhookURL, Events: []string{"push"}, Active: true, Secret: secret})
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("Webhook created successfully for repository '%s' with URL '%s'\n", repoName, webhookURL)
+		return hook, nil
+	}
+
+	events := existing.Events
+	if !slices.Contains(events, "push") && !slices.Contains(events, "*") {
+		events = append(events, "push")
+	}
+	hook, err := c.UpdateWebhook(orgName, repoName, existing.ID, WebhookOptions{URL: webhookURL, Events: events, Active: true, Secret: secret})
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("Webhook for repository '%s' with URL '%s' already exists; updated it.\n", repoName, webhookURL)
+	return hook, nil
+}
+
+// ListWebhooks returns every hook configured on a repo.
+func (c *Client) ListWebhooks(orgName string, repoName string) ([]*Webhook, error) {
+	ctx := context.Background()
+
+	var all []*Webhook
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		hooks, resp, err := c.client.Repositories.ListHooks(ctx, orgName, repoName, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error listing webhooks for repository '%s': %v", repoName, err)
+		}
+		for _, hook := range hooks {
+			all = append(all, newWebhook(hook))
+		}
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// FindWebhook returns the repo hook whose config URL is webhookURL, or nil if
+// there is none. A trailing slash is ignored when comparing URLs.
+func (c *Client) FindWebhook(orgName string, repoName string, webhookURL string) (*Webhook, error) {
+	hooks, err := c.ListWebhooks(orgName, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hook := range hooks {
+		if sameURL(hook.URL, webhookURL) {
+			return hook, nil
+		}
+	}
+	return nil, nil
+}
+
+func sameURL(a, b string) bool {
+	return strings.TrimSuffix(a, "/") == strings.TrimSuffix(b, "/")
+}
+
+// GetWebhook fetches a repo hook by ID.
+func (c *Client) GetWebhook(orgName string, repoName string, id int64) (*Webhook, error) {
+	hook, _, err := c.client.Repositories.GetHook(context.Background(), orgName, repoName, id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching webhook %d for repository '%s': %v", id, repoName, err)
+	}
+	return newWebhook(hook), nil
+}
+
+// AddWebhook creates a repo hook without checking for an existing one.
+func (c *Client) AddWebhook(orgName string, repoName string, opts WebhookOptions) (*Webhook, error) {
+	hook, _, err := c.client.Repositories.CreateHook(context.Background(), orgName, repoName, opts.hook())
+	if err != nil {
+		return nil, fmt.Errorf("error creating webhook for repository '%s': %v", repoName, err)
+	}
+	return newWebhook(hook), nil
+}
+
+// UpdateWebhook replaces the URL, events and active state of a repo hook, and
+// its secret if opts has one. GitHub replaces the whole config on update,
+// dropping the secret, so without a new secret the config is only sent if the
+// URL changes.
+func (c *Client) UpdateWebhook(orgName string, repoName string, id int64, opts WebhookOptions) (*Webhook, error) {
+	update := opts.hook()
+	if opts.Secret == "" {
+		current, err := c.GetWebhook(orgName, repoName, id)
+		if err != nil {
+			return nil, err
+		}
+		if sameURL(current.URL, opts.URL) {
+			update.Config = nil
+		}
+	}
+
+	hook, _, err := c.client.Repositories.EditHook(context.Background(), orgName, repoName, id, update)
+	if err != nil {
+		return nil, fmt.Errorf("error updating webhook %d for repository '%s': %v", id, repoName, err)
+	}
+	return newWebhook(hook), nil
+}
+
+// DeleteWebhook removes a repo hook.
+func (c *Client) DeleteWebhook(orgName string, repoName string, id int64) error {
+	_, err := c.client.Repositories.DeleteHook(context.Background(), orgName, repoName, id)
+	if err != nil {
+		return fmt.Errorf("error deleting webhook %d for repository '%s': %v", id, repoName, err)
+	}
+	return nil
+}
+
+// PingWebhook asks GitHub to send a ping event to a repo hook.
+func (c *Client) PingWebhook(orgName string, repoName string, id int64) error {
+	_, err := c.client.Repositories.PingHook(context.Background(), orgName, repoName, id)
+	if err != nil {
+		return fmt.Errorf("error pinging webhook %d for repository '%s': %v", id, repoName, err)
+	}
+	return nil
+}
+
+// Succeeded reports whether the receiver answered the delivery with a 2xx.
+func (d *WebhookDelivery) Succeeded() bool {
+	return d.StatusCode >= 200 && d.StatusCode < 300
+}
+
+// ListWebhookDeliveries returns up to limit of a hook's most recent
+// deliveries made since the given time, newest first. A limit of 0 and a zero
+// since return every delivery GitHub still keeps.
+func (c *Client) ListWebhookDeliveries(orgName string, repoName string, id int64, limit int, since time.Time) ([]*WebhookDelivery, error) {
+	ctx := context.Background()
+
+	var all []*WebhookDelivery
+	opts := &github.ListCursorOptions{PerPage: 100}
+	for {
+		deliveries, resp, err := c.client.Repositories.ListHookDeliveries(ctx, orgName, repoName, id, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error listing deliveries of webhook %d for repository '%s': %v", id, repoName, err)
+		}
+		for _, d := range deliveries {
+			delivery := newWebhookDelivery(d)
+			if delivery.DeliveredAt.Before(since) {
+				return all, nil
+			}
+			all = append(all, delivery)
+			if limit > 0 && len(all) == limit {
+				return all, nil
+			}
+		}
+		if resp.Cursor == "" {
+			return all, nil
+		}
+		opts.Cursor = resp.Cursor
+	}
+}
+
+// RedeliverWebhookDelivery asks GitHub to send a past delivery again.
+func (c *Client) RedeliverWebhookDelivery(orgName string, repoName string, hookID int64, deliveryID int64) error {
+	_, _, err := c.client.Repositories.RedeliverHookDelivery(context.Background(), orgName, repoName, hookID, deliveryID)
+	if err != nil {
+		// GitHub queues redeliveries and answers 202, which go-github
+		// reports as an AcceptedError.
+		var accepted *github.AcceptedError
+		if errors.As(err, &accepted) {
+			return nil
+		}
+		return fmt.Errorf("error redelivering delivery %d of webhook %d for repository '%s': %v", deliveryID, hookID, repoName, err)
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package jenkins
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const pollInterval = 2 * time.Second
+
+// ErrBuildTimeout is returned by TriggerBuild when the build doesn't finish
+// within the timeout. The build itself keeps running in Jenkins.
+var ErrBuildTimeout = errors.New("timed out waiting for the build")
+
+// deadline is when polling gives up; the zero value never does.
+type deadline time.Time
+
+func newDeadline(timeout time.Duration) deadline {
+	if timeout <= 0 {
+		return deadline{}
+	}
+	return deadline(time.Now().Add(timeout))
+}
+
+// sleep waits for the next poll, or returns ErrBuildTimeout if the deadline
+// passes first.
+func (d deadline) sleep() error {
+	t := time.Time(d)
+	if !t.IsZero() && time.Until(t) < pollInterval {
+		return ErrBuildTimeout
+	}
+	time.Sleep(pollInterval)
+	return nil
+}
+
+// Build is a single run of a Jenkins job.
+type Build struct {
+	Number    int           `json:"number"`
+	URL       string        `json:"url"`
+	Result    string        `json:"result"`
+	Building  bool          `json:"building"`
+	Duration  int64         `json:"duration"`  // milliseconds, 0 while building
+	Timestamp int64         `json:"timestamp"` // start time, Unix milliseconds
+	Actions   []buildAction `json:"actions"`
+}
+
+// buildAction covers the build actions job-status reports on: CauseAction and
+// the git plugin's BuildData. Other actions decode to empty values.
+type buildAction struct {
+	Causes []struct {
+		ShortDescription string `json:"shortDescription"`
+	} `json:"causes"`
+	LastBuiltRevision *struct {
+		SHA1 string `json:"SHA1"`
+	} `json:"lastBuiltRevision"`
+}
+
+// StartTime returns when the build started.
+func (b Build) StartTime() time.Time {
+	return time.UnixMilli(b.Timestamp)
+}
+
+// Elapsed returns the build duration, or the time since it started while it
+// is still running.
+func (b Build) Elapsed() time.Duration {
+	if b.Building {
+		return time.Since(b.StartTime()).Round(time.Second)
+	}
+	return (time.Duration(b.Duration) * time.Millisecond).Round(time.Second)
+}
+
+// Cause returns the description of what triggered the build.
+func (b Build) Cause() string {
+	var causes []string
+	for _, action := range b.Actions {
+		for _, cause := range action.Causes {
+			causes = append(causes, cause.ShortDescription)
+		}
+	}
+	return strings.Join(causes, "; ")
+}
+
+// CommitSHA returns the commit the build checked out, as recorded by the git
+// plugin, or "" if the build has no git data.
+func (b Build) CommitSHA() string {
+	for _, action := range b.Actions {
+		if action.LastBuiltRevision != nil {
+			return action.LastBuiltRevision.SHA1
+		}
+	}
+	return ""
+}
+
+// queueItem is the part of a Jenkins queue item needed to follow it to a build.
+type queueItem struct {
+	Cancelled  bool   `json:"cancelled"`
+	Why        string `json:"why"`
+	Executable *Build `json:"executable"`
+}
+
+// TriggerBuild queues a build of the job, waits for it to start, streams its
+// console log to out until it finishes and returns the finished build.
+// Parameters, if any, are sent to /buildWithParameters instead of /build.
+// If timeout is positive, it gives up with an error wrapping ErrBuildTimeout
+// once the build has been queued or running for that long.
+func (jc *APIClient) TriggerBuild(job JobPath, params map[string]string, timeout time.Duration, out io.Writer) (*Build, error) {
+	queueURL, err := jc.queueBuild(job, params)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(out, "Build of '%s' queued at %s\n", job, queueURL)
+	d := newDeadline(timeout)
+
+	build, err := jc.waitForExecutable(queueURL, d, out)
+	if errors.Is(err, ErrBuildTimeout) {
+		return nil, fmt.Errorf("%w to leave the queue after %s; it is still queued at %s", err, timeout, queueURL)
+	}
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(out, "Build #%d started: %s\n", build.Number, build.URL)
+
+	err = jc.streamBuildLog(build.URL, d, out)
+	if err == nil {
+		var finished *Build
+		if finished, err = jc.waitForResult(build.URL, d); err == nil {
+			return finished, nil
+		}
+	}
+	if errors.Is(err, ErrBuildTimeout) {
+		return nil, fmt.Errorf("%w to finish after %s; it is still running at %s", err, timeout, build.URL)
+	}
+	return nil, err
+}
+
+func (jc *APIClient) queueBuild(job JobPath, params map[string]string) (string, error) {
+	apiURL := fmt.Sprintf("%s%s/build", jc.baseURL(), job.URLPath())
+	var body []byte
+	contentType := ""
+
+	if len(params) > 0 {
+		apiURL = fmt.Sprintf("%s%s/buildWithParameters", jc.baseURL(), job.URLPath())
+		form := url.Values{}
+		for key, value := range params {
+			form.Set(key, value)
+		}
+		body = []byte(form.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	}
+
+	resp, respBody, err := jc.doRequest("POST", apiURL, body, contentType)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(respBody))
+	}
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", fmt.Errorf("Jenkins did not return a queue item for job '%s'", job)
+	}
+	return location, nil
+}
+
+// waitForExecutable polls a queue item until Jenkins assigns it a build.
+func (jc *APIClient) waitForExecutable(queueURL string, d deadline, out io.Writer) (*Build, error) {
+	apiURL := strings.TrimSuffix(queueURL, "/") + "/api/json"
+
+	lastReason := ""
+	for {
+		var item queueItem
+		if err := jc.getJSON(apiURL, &item); err != nil {
+			return nil, fmt.Errorf("failed to read queue item: %v", err)
+		}
+
+		if item.Cancelled {
+			return nil, fmt.Errorf("queued build was cancelled")
+		}
+		if item.Executable != nil {
+			return item.Executable, nil
+		}
+
+		if item.Why != "" && item.Why != lastReason {
+			fmt.Fprintf(out, "Waiting in queue: %s\n", item.Why)
+			lastReason = item.Why
+		}
+		if err := d.sleep(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// StreamBuildLog writes the console log of a build to out, following it via
+// logText/progressiveText until Jenkins reports there is no more data.
+func (jc *APIClient) StreamBuildLog(buildURL string, out io.Writer) error {
+	return jc.streamBuildLog(buildURL, deadline{}, out)
+}
+
+func (jc *APIClient) streamBuildLog(buildURL string, d deadline, out io.Writer) error {
+	start := 0
+	for {
+		apiURL := fmt.Sprintf("%s/logText/progressiveText?start=%d", strings.TrimSuffix(buildURL, "/"), start)
+
+		resp, body, err := jc.doRequest("GET", apiURL, nil, "")
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
+		}
+
+		if _, err := out.Write(body); err != nil {
+			return fmt.Errorf("failed to write build log: %v", err)
+		}
+
+		if size, err := strconv.Atoi(resp.Header.Get("X-Text-Size")); err == nil {
+			start = size
+		}
+		if resp.Header.Get("X-More-Data") != "true" {
+			return nil
+		}
+		if err := d.sleep(); err != nil {
+			return err
+		}
+	}
+}
+
+// waitForResult polls a build until Jenkins has recorded its result.
+func (jc *APIClient) waitForResult(buildURL string, d deadline) (*Build, error) {
+	apiURL := strings.TrimSuffix(buildURL, "/") + "/api/json?tree=number,url,result,building"
+
+	for {
+		var build Build
+		if err := jc.getJSON(apiURL, &build); err != nil {
+			return nil, fmt.Errorf("failed to read build status: %v", err)
+		}
+		if !build.Building && build.Result != "" {
+			return &build, nil
+		}
+		if err := d.sleep(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// getJSON fetches a Jenkins API URL and decodes its JSON response into v.
+func (jc *APIClient) getJSON(apiURL string, v interface{}) error {
+	resp, body, err := jc.doRequest("GET", apiURL, nil, "")
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to parse Jenkins response: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package jenkins
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// CredentialStore locates a credentials domain. An empty Folder means the
+// system store; an empty Domain means the global domain.
+type CredentialStore struct {
+	Folder JobPath
+	Domain string
+}
+
+func (s CredentialStore) urlPath() string {
+	store := "system"
+	if len(s.Folder) > 0 {
+		store = "folder"
+	}
+
+	domain := s.Domain
+	if domain == "" {
+		domain = "_"
+	}
+	return fmt.Sprintf("%s/credentials/store/%s/domain/%s", s.Folder.URLPath(), store, url.PathEscape(domain))
+}
+
+func (s CredentialStore) String() string {
+	domain := s.Domain
+	if domain == "" {
+		domain = "global"
+	}
+	if len(s.Folder) == 0 {
+		return "system/" + domain
+	}
+	return s.Folder.String() + "/" + domain
+}
+
+// Credential describes a stored credential. Jenkins never returns secrets.
+type Credential struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	TypeName    string `json:"typeName"`
+	Description string `json:"description"`
+}
+
+// CredentialType selects the kind of credential CredentialSpec creates.
+type CredentialType string
+
+const (
+	UsernamePassword CredentialType = "username-password"
+	SecretText       CredentialType = "secret-text"
+)
+
+// CredentialSpec holds the values for creating or updating a credential.
+// Username and Password apply to UsernamePassword, Secret to SecretText.
+type CredentialSpec struct {
+	Type        CredentialType
+	ID          string
+	Description string
+	Username    string
+	Password    string
+	Secret      string
+}
+
+type usernamePasswordXML struct {
+	XMLName     xml.Name `xml:"com.cloudbees.plugins.credentials.impl.UsernamePasswordCredentialsImpl"`
+	Scope       string   `xml:"scope"`
+	ID          string   `xml:"id"`
+	Description string   `xml:"description"`
+	Username    string   `xml:"username"`
+	Password    string   `xml:"password"`
+}
+
+type secretTextXML struct {
+	XMLName     xml.Name `xml:"org.jenkinsci.plugins.plaincredentials.impl.StringCredentialsImpl"`
+	Scope       string   `xml:"scope"`
+	ID          string   `xml:"id"`
+	Description string   `xml:"description"`
+	Secret      string   `xml:"secret"`
+}
+
+// xml renders the spec in the format the credentials plugin accepts.
+func (c CredentialSpec) xml() ([]byte, error) {
+	switch c.Type {
+	case UsernamePassword:
+		return xml.Marshal(usernamePasswordXML{Scope: "GLOBAL", ID: c.ID, Description: c.Description, Username: c.Username, Password: c.Password})
+	case SecretText:
+		return xml.Marshal(secretTextXML{Scope: "GLOBAL", ID: c.ID, Description: c.Description, Secret: c.Secret})
+	default:
+		return nil, fmt.Errorf("unsupported credential type %q", c.Type)
+	}
+}
+
+// ListCredentials returns the credentials in a store's domain.
+func (jc *APIClient) ListCredentials(store CredentialStore) ([]Credential, error) {
+	apiURL := fmt.Sprintf("%s%s/api/json?tree=%s", jc.baseURL(), store.urlPath(),
+		url.QueryEscape("credentials[id,displayName,typeName,description]"))
+
+	var domain struct {
+		Credentials []Credential `json:"credentials"`
+	}
+	if err := jc.getJSON(apiURL, &domain); err != nil {
+		return nil, fmt.Errorf("failed to list credentials in '%s': %v", store, err)
+	}
+	return domain.Credentials, nil
+}
+
+// CredentialExists reports whether a credential ID exists in a store's domain.
+// A store that does not exist contains no credentials.
+func (jc *APIClient) CredentialExists(store CredentialStore, id string) (bool, error) {
+	apiURL := fmt.Sprintf("%s%s/credential/%s/api/json?tree=id", jc.baseURL(), store.urlPath(), url.PathEscape(id))
+
+	resp, body, err := jc.doRequest("GET", apiURL, nil, "")
+	if err != nil {
+		return false, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
+	}
+}
+
+// FindCredential reports whether a job at the given path can use a credential
+// ID from the global domain, checking the stores of its enclosing folders and
+// then the system store.
+func (jc *APIClient) FindCredential(job JobPath, id string) (bool, error) {
+	folder := job.Parent()
+	for i := len(folder); i >= 0; i-- {
+		found, err := jc.CredentialExists(CredentialStore{Folder: folder[:i]}, id)
+		if err != nil {
+			return false, err
+		}
+		if found {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CreateCredential adds a credential to a store's domain.
+func (jc *APIClient) CreateCredential(store CredentialStore, spec CredentialSpec) error {
+	config, err := spec.xml()
+	if err != nil {
+		return err
+	}
+
+	apiURL := fmt.Sprintf("%s%s/createCredentials", jc.baseURL(), store.urlPath())
+	resp, body, err := jc.doRequest("POST", apiURL, config, "application/xml")
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
+	}
+	return nil
+}
+
+// SaveCredential creates a credential in a store's domain, or replaces it if
+// the ID already exists. It reports whether the credential was created.
+func (jc *APIClient) SaveCredential(store CredentialStore, spec CredentialSpec) (bool, error) {
+	exists, err := jc.CredentialExists(store, spec.ID)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, jc.UpdateCredential(store, spec)
+	}
+	return true, jc.CreateCredential(store, spec)
+}
+
+// DeleteCredential removes a credential from a store's domain.
+func (jc *APIClient) DeleteCredential(store CredentialStore, id string) error {
+	apiURL := fmt.Sprintf("%s%s/credential/%s/doDelete", jc.baseURL(), store.urlPath(), url.PathEscape(id))
+	resp, body, err := jc.doRequest("POST", apiURL, nil, "")
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
+	}
+	return nil
+}
+
+// UpdateCredential replaces an existing credential in a store's domain.
+func (jc *APIClient) UpdateCredential(store CredentialStore, spec CredentialSpec) error {
+	config, err := spec.xml()
+	if err != nil {
+		return err
+	}
+
+	apiURL := fmt.Sprintf("%s%s/credential/%s/config.xml", jc.baseURL(), store.urlPath(), url.PathEscape(spec.ID))
+	resp, body, err := jc.doRequest("POST", apiURL, config, "application/xml")
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
+	}
+	return nil
+}
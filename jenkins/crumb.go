@@ -0,0 +1,81 @@
+package jenkins
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// crumb is a CSRF token issued by Jenkins' crumb issuer. Jenkins ties the
+// crumb to the session that requested it, so it is only valid alongside the
+// session cookie kept in the client's cookie jar.
+type crumb struct {
+	Field string `json:"crumbRequestField"`
+	Value string `json:"crumb"`
+}
+
+// fetchCrumb requests a new crumb from /crumbIssuer/api/json. A nil crumb and
+// nil error mean CSRF protection is disabled on the Jenkins server.
+func (jc *APIClient) fetchCrumb() (*crumb, error) {
+	apiURL := jc.baseURL() + "/crumbIssuer/api/json"
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create crumb request: %v", err)
+	}
+	req.Header.Set("Authorization", jc.basicAuth())
+
+	resp, err := jc.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request crumb from Jenkins: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read crumb response: %v", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Jenkins crumb issuer error: %s, response: %s", resp.Status, string(body))
+	}
+
+	var c crumb
+	if err := json.Unmarshal(body, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse crumb response: %v", err)
+	}
+	return &c, nil
+}
+
+// ensureCrumb returns the cached crumb, fetching one first if needed.
+func (jc *APIClient) ensureCrumb() (*crumb, error) {
+	if jc.crumbFetched {
+		return jc.crumb, nil
+	}
+
+	c, err := jc.fetchCrumb()
+	if err != nil {
+		return nil, err
+	}
+	jc.crumb = c
+	jc.crumbFetched = true
+	return c, nil
+}
+
+// resetCrumb drops the cached crumb so the next mutating request fetches a
+// fresh one.
+func (jc *APIClient) resetCrumb() {
+	jc.crumb = nil
+	jc.crumbFetched = false
+}
+
+// isCrumbError reports whether a Jenkins response rejected the request
+// because of a missing or stale crumb.
+func isCrumbError(resp *http.Response, body []byte) bool {
+	return resp.StatusCode == http.StatusForbidden && strings.Contains(string(body), "No valid crumb")
+}
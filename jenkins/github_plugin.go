@@ -0,0 +1,62 @@
+package jenkins
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// The GitHub plugin has no REST endpoint for its global config, so the shared
+// secrets are read and updated through the script console. %s is the
+// credential ID as a Groovy string literal.
+const hookSecretScript = `
+import jenkins.model.GlobalConfiguration
+import org.jenkinsci.plugins.github.config.GitHubPluginConfig
+import org.jenkinsci.plugins.github.config.HookSecretConfig
+
+def id = %s
+def config = GlobalConfiguration.all().get(GitHubPluginConfig)
+if (config.hookSecretConfigs.any { it.credentialsId == id }) {
+  println 'present'
+} else {
+  config.hookSecretConfigs = config.hookSecretConfigs + [new HookSecretConfig(id)]
+  config.save()
+  println 'added'
+}
+`
+
+// EnsureHookSecret makes sure the GitHub plugin checks webhook signatures
+// against the given secret text credential, and reports whether it had to
+// add it to the plugin's shared secrets. It needs the Overall/Administer
+// permission that the script console requires.
+func (jc *APIClient) EnsureHookSecret(credentialsID string) (bool, error) {
+	script := fmt.Sprintf(hookSecretScript, groovyString(credentialsID))
+	form := url.Values{"script": {script}}
+
+	resp, body, err := jc.doRequest("POST", jc.baseURL()+"/scriptText", []byte(form.Encode()), "application/x-www-form-urlencoded")
+	if err != nil {
+		return false, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
+	}
+
+	// Script failures, e.g. a missing GitHub plugin, still return 200.
+	switch result := strings.TrimSpace(string(body)); result {
+	case "added":
+		return true, nil
+	case "present":
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected script console output: %s", result)
+	}
+}
+
+// groovyString quotes s as a single-quoted Groovy string, which does no
+// interpolation.
+func groovyString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
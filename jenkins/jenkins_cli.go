@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/cookiejar"
+	"net/url"
 	"os"
 	"strings"
 )
@@ -15,14 +17,21 @@ type APIClient struct {
 	Username   string
 	APIToken   string
 	httpClient *http.Client
+
+	// CSRF crumb cache; the cookie jar on httpClient holds the matching session.
+	crumb        *crumb
+	crumbFetched bool
 }
 
 func NewAPIClient() *APIClient {
+	// The jar keeps the session cookie the crumb was issued for.
+	jar, _ := cookiejar.New(nil)
+
 	return &APIClient{
 		JenkinsURL: os.Getenv("JENKINS_URL"),
 		Username:   os.Getenv("JENKINS_USER_ID"),
 		APIToken:   os.Getenv("JENKINS_API_TOKEN"),
-		httpClient: &http.Client{},
+		httpClient: &http.Client{Jar: jar},
 	}
 }
 
@@ -30,75 +39,189 @@ func (jc *APIClient) basicAuth() string {
 	return "Basic " + base64.StdEncoding.EncodeToString([]byte(jc.Username+":"+jc.APIToken))
 }
 
-func (jc *APIClient) CreateJob(jobName string, configXMLPath string) error {
-	// Read and update the job configuration XML
-	configData, err := os.ReadFile(configXMLPath)
+func (jc *APIClient) baseURL() string {
+	return strings.TrimSuffix(jc.JenkinsURL, "/")
+}
+
+// doRequest sends an authenticated request to Jenkins and returns the response
+// together with its body. Mutating requests carry the CSRF crumb; if Jenkins
+// rejects the crumb, it is refreshed and the request retried once.
+func (jc *APIClient) doRequest(method string, apiURL string, body []byte, contentType string) (*http.Response, []byte, error) {
+	resp, respBody, err := jc.send(method, apiURL, body, contentType)
 	if err != nil {
-		return fmt.Errorf("failed to read XML file: %v", err)
+		return nil, nil, err
 	}
 
-	updatedConfig := strings.ReplaceAll(string(configData), "REPO_NAME", jobName)
+	if method != "GET" && isCrumbError(resp, respBody) {
+		jc.resetCrumb()
+		resp, respBody, err = jc.send(method, apiURL, body, contentType)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 
-	// Construct the API URL
-	apiURL := fmt.Sprintf("%s/createItem?name=%s", jc.JenkinsURL, jobName)
+	return resp, respBody, nil
+}
 
-	// Create the HTTP request
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer([]byte(updatedConfig)))
+func (jc *APIClient) send(method string, apiURL string, body []byte, contentType string) (*http.Response, []byte, error) {
+	req, err := http.NewRequest(method, apiURL, bytes.NewReader(body))
 	if err != nil {
-		return fmt.Errorf("failed to create HTTP request: %v", err)
+		return nil, nil, fmt.Errorf("failed to create HTTP request: %v", err)
 	}
 
-	req.Header.Set("Content-Type", "application/xml")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 	req.Header.Set("Authorization", jc.basicAuth())
 
-	// Make the request
+	if method != "GET" {
+		c, err := jc.ensureCrumb()
+		if err != nil {
+			return nil, nil, err
+		}
+		if c != nil {
+			req.Header.Set(c.Field, c.Value)
+		}
+	}
+
 	resp, err := jc.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request to Jenkins: %v", err)
+		return nil, nil, fmt.Errorf("failed to send request to Jenkins: %v", err)
 	}
 	defer resp.Body.Close()
 
-	// Read the response
-	body, err := io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %v", err)
+		return nil, nil, fmt.Errorf("failed to read response: %v", err)
 	}
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
+	return resp, respBody, nil
+}
+
+// CreateJob creates a job at the given path from a rendered config XML,
+// creating any missing parent folders first.
+func (jc *APIClient) CreateJob(job JobPath, config []byte) error {
+	if err := jc.EnsureFolder(job.Parent()); err != nil {
+		return err
 	}
 
-	fmt.Printf("Job '%s' created successfully.\n", jobName)
+	if err := jc.createItem(job, config); err != nil {
+		return err
+	}
+
+	fmt.Printf("Job '%s' created successfully.\n", job)
 	return nil
 }
 
-func (jc *APIClient) DeleteJob(jobName string) error {
+// GetJobConfig returns the live config.xml of a job.
+func (jc *APIClient) GetJobConfig(job JobPath) ([]byte, error) {
+	apiURL := fmt.Sprintf("%s%s/config.xml", jc.baseURL(), job.URLPath())
 
-	jenkinsURL := strings.TrimSuffix(jc.JenkinsURL, "/")
-	apiURL := fmt.Sprintf("%s/job/%s/doDelete", jenkinsURL, jobName)
+	resp, body, err := jc.doRequest("GET", apiURL, nil, "")
+	if err != nil {
+		return nil, err
+	}
 
-	req, err := http.NewRequest("POST", apiURL, nil)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
+	}
+	return body, nil
+}
+
+// UpdateJobConfig replaces the config.xml of an existing job.
+func (jc *APIClient) UpdateJobConfig(job JobPath, config []byte) error {
+	if err := jc.postJobConfig(job, config); err != nil {
+		return err
+	}
+
+	fmt.Printf("Job '%s' updated successfully.\n", job)
+	return nil
+}
+
+func (jc *APIClient) postJobConfig(job JobPath, config []byte) error {
+	apiURL := fmt.Sprintf("%s%s/config.xml", jc.baseURL(), job.URLPath())
+
+	resp, body, err := jc.doRequest("POST", apiURL, config, "application/xml")
 	if err != nil {
-		return fmt.Errorf("failed to create HTTP request: %v", err)
+		return err
 	}
 
-	req.Header.Set("Authorization", jc.basicAuth())
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
+	}
+	return nil
+}
 
-	resp, err := jc.httpClient.Do(req)
+// CopyJob creates a job at to as a copy of the job at from, creating any
+// missing parent folders first.
+func (jc *APIClient) CopyJob(from JobPath, to JobPath) error {
+	if err := jc.EnsureFolder(to.Parent()); err != nil {
+		return err
+	}
+
+	apiURL := fmt.Sprintf("%s%s/createItem?name=%s&mode=copy&from=%s", jc.baseURL(), to.Parent().URLPath(),
+		url.QueryEscape(to.Name()), url.QueryEscape("/"+from.String()))
+
+	resp, body, err := jc.doRequest("POST", apiURL, nil, "")
 	if err != nil {
-		return fmt.Errorf("failed to send request to Jenkins: %v", err)
+		return err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
+	}
+
+	// Jobs copied through the API stay unbuildable until their config is
+	// saved once, so post it back unchanged.
+	config, err := jc.GetJobConfig(to)
+	if err != nil {
+		return err
+	}
+	if err := jc.postJobConfig(to, config); err != nil {
+		return err
+	}
+
+	fmt.Printf("Job '%s' copied to '%s' successfully.\n", from, to)
+	return nil
+}
+
+func (jc *APIClient) DeleteJob(job JobPath) error {
+
+	apiURL := fmt.Sprintf("%s%s/doDelete", jc.baseURL(), job.URLPath())
+
+	resp, body, err := jc.doRequest("POST", apiURL, nil, "")
 	if err != nil {
-		return fmt.Errorf("failed to read response: %v", err)
+		return err
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
 	}
 
-	fmt.Printf("Job '%s' deleted successfully.\n", jobName)
+	fmt.Printf("Job '%s' deleted successfully.\n", job)
+	return nil
+}
+
+// EnableJob re-enables a disabled job.
+func (jc *APIClient) EnableJob(job JobPath) error {
+	return jc.postJobAction(job, "enable")
+}
+
+// DisableJob stops a job from building while keeping its history.
+func (jc *APIClient) DisableJob(job JobPath) error {
+	return jc.postJobAction(job, "disable")
+}
+
+func (jc *APIClient) postJobAction(job JobPath, action string) error {
+	apiURL := fmt.Sprintf("%s%s/%s", jc.baseURL(), job.URLPath(), action)
+
+	resp, body, err := jc.doRequest("POST", apiURL, nil, "")
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
+	}
 	return nil
 }
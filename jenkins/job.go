@@ -0,0 +1,163 @@
+package jenkins
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// maxFolderDepth bounds how many folder levels ListJobs descends into.
+const maxFolderDepth = 10
+
+// Job is a Jenkins job along with its most recent builds.
+type Job struct {
+	Name     string  `json:"name"`
+	FullName string  `json:"fullName"`
+	URL      string  `json:"url"`
+	Color    string  `json:"color"`
+	Class    string  `json:"_class"`
+	Builds   []Build `json:"builds"`
+	Jobs     []Job   `json:"jobs"` // items inside a folder
+
+	// Project is the full name of the multibranch project a branch job
+	// listed by ListJobs belongs to, and empty for other jobs.
+	Project string `json:"-"`
+}
+
+// orgFolderClass is the class of GitHub organization folders, which hold a
+// multibranch project per repo.
+const orgFolderClass = "jenkins.branch.OrganizationFolder"
+
+// IsFolder reports whether the item holds other items rather than builds:
+// a folder, an organization folder or a multibranch project. Items fetched
+// with their jobs, as ListJobs does, also count when they have a jobs array,
+// which covers container types from other plugins.
+func (j *Job) IsFolder() bool {
+	switch j.Class {
+	case folderClass, orgFolderClass, multibranchClass:
+		return true
+	default:
+		return j.Jobs != nil
+	}
+}
+
+// Type returns the job's type, or "" for other kinds of items.
+func (j *Job) Type() JobType {
+	switch j.Class {
+	case multibranchClass:
+		return Multibranch
+	case "org.jenkinsci.plugins.workflow.job.WorkflowJob":
+		return Pipeline
+	default:
+		return ""
+	}
+}
+
+// Status describes the job's last build result from its ball color, e.g.
+// "passing", "failing" or "disabled".
+func (j *Job) Status() string {
+	switch strings.TrimSuffix(j.Color, "_anime") {
+	case "blue":
+		return "passing"
+	case "red":
+		return "failing"
+	case "yellow":
+		return "unstable"
+	case "aborted":
+		return "aborted"
+	case "disabled":
+		return "disabled"
+	case "notbuilt", "grey":
+		return "not built"
+	default:
+		return j.Color
+	}
+}
+
+const buildTree = "number,url,result,building,duration,timestamp," +
+	"actions[causes[shortDescription],lastBuiltRevision[SHA1]]"
+
+// GetJob fetches a job and up to buildCount of its most recent builds, newest
+// first.
+func (jc *APIClient) GetJob(job JobPath, buildCount int) (*Job, error) {
+	tree := fmt.Sprintf("name,fullName,url,color,builds[%s]{0,%d}", buildTree, buildCount)
+	apiURL := fmt.Sprintf("%s%s/api/json?tree=%s", jc.baseURL(), job.URLPath(), url.QueryEscape(tree))
+
+	var j Job
+	if err := jc.getJSON(apiURL, &j); err != nil {
+		return nil, fmt.Errorf("failed to fetch job '%s': %v", job, err)
+	}
+	return &j, nil
+}
+
+// IsBuilding reports whether any of the job's fetched builds is still running.
+func (j *Job) IsBuilding() bool {
+	for _, build := range j.Builds {
+		if build.Building {
+			return true
+		}
+	}
+	return false
+}
+
+// ListJobs returns every job inside the folder (the Jenkins root if folder is
+// empty), descending into subfolders, organization folders and multibranch
+// projects, whose branch jobs are listed in their place. Each job's FullName
+// is its path from the Jenkins root.
+func (jc *APIClient) ListJobs(folder JobPath) ([]Job, error) {
+	tree := "jobs[name,url,color]"
+	for i := 0; i < maxFolderDepth; i++ {
+		tree = fmt.Sprintf("jobs[name,url,color,%s]", tree)
+	}
+	apiURL := fmt.Sprintf("%s%s/api/json?tree=%s", jc.baseURL(), folder.URLPath(), url.QueryEscape(tree))
+
+	var root Job
+	if err := jc.getJSON(apiURL, &root); err != nil {
+		return nil, fmt.Errorf("failed to list jobs in '%s': %v", folder, err)
+	}
+
+	var jobs []Job
+	var walk func(parent JobPath, project string, items []Job)
+	walk = func(parent JobPath, project string, items []Job) {
+		for _, item := range items {
+			path := parent.Join(item.Name)
+			if item.IsFolder() {
+				itemProject := ""
+				if item.Type() == Multibranch {
+					itemProject = path.String()
+				}
+				walk(path, itemProject, item.Jobs)
+				continue
+			}
+			item.FullName = path.String()
+			item.Project = project
+			item.Jobs = nil
+			jobs = append(jobs, item)
+		}
+	}
+	walk(folder, "", root.Jobs)
+
+	return jobs, nil
+}
+
+// ProjectJob resolves the job a project owns from a job path. If the path
+// names a branch job inside a multibranch project, the multibranch project is
+// returned instead, since deleting only the branch job would leave the
+// project to recreate it on the next scan.
+func (jc *APIClient) ProjectJob(job JobPath) (JobPath, JobType, error) {
+	if parent := job.Parent(); len(parent) > 0 {
+		parentJob, err := jc.GetJob(parent, 0)
+		if err != nil {
+			return nil, "", err
+		}
+		if parentJob.Type() == Multibranch {
+			return parent, Multibranch, nil
+		}
+	}
+
+	j, err := jc.GetJob(job, 0)
+	if err != nil {
+		return nil, "", err
+	}
+	return job, j.Type(), nil
+}
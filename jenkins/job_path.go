@@ -0,0 +1,119 @@
+package jenkins
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const folderClass = "com.cloudbees.hudson.plugins.folder.Folder"
+
+// JobPath is the location of a Jenkins item, one element per folder level,
+// e.g. "workshops/2026/my-repo" is JobPath{"workshops", "2026", "my-repo"}.
+type JobPath []string
+
+// ParseJobPath splits a slash-separated job path, ignoring empty segments.
+func ParseJobPath(s string) JobPath {
+	var p JobPath
+	for _, segment := range strings.Split(s, "/") {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			p = append(p, segment)
+		}
+	}
+	return p
+}
+
+// Name returns the final segment of the path, the item's own name.
+func (p JobPath) Name() string {
+	if len(p) == 0 {
+		return ""
+	}
+	return p[len(p)-1]
+}
+
+// Parent returns the path of the folder containing the item.
+func (p JobPath) Parent() JobPath {
+	if len(p) == 0 {
+		return nil
+	}
+	return p[:len(p)-1]
+}
+
+// Join returns a new path with the given segments appended.
+func (p JobPath) Join(segments ...string) JobPath {
+	joined := make(JobPath, 0, len(p)+len(segments))
+	joined = append(joined, p...)
+	return append(joined, segments...)
+}
+
+// URLPath turns a/b/c into /job/a/job/b/job/c. The root path is "".
+func (p JobPath) URLPath() string {
+	var b strings.Builder
+	for _, segment := range p {
+		b.WriteString("/job/")
+		b.WriteString(url.PathEscape(segment))
+	}
+	return b.String()
+}
+
+func (p JobPath) String() string {
+	return strings.Join(p, "/")
+}
+
+// JobExists reports whether an item (job or folder) exists at the given path.
+func (jc *APIClient) JobExists(job JobPath) (bool, error) {
+	apiURL := fmt.Sprintf("%s%s/api/json?tree=name", jc.baseURL(), job.URLPath())
+
+	resp, body, err := jc.doRequest("GET", apiURL, nil, "")
+	if err != nil {
+		return false, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
+	}
+}
+
+// EnsureFolder creates every folder along the path that does not exist yet.
+func (jc *APIClient) EnsureFolder(folder JobPath) error {
+	for i := range folder {
+		current := folder[:i+1]
+
+		exists, err := jc.JobExists(current)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		config := fmt.Sprintf("<%s/>", folderClass)
+		if err := jc.createItem(current, []byte(config)); err != nil {
+			return fmt.Errorf("failed to create folder '%s': %v", current, err)
+		}
+		fmt.Printf("Folder '%s' created.\n", current)
+	}
+	return nil
+}
+
+// createItem posts an item config to the createItem endpoint of the item's
+// parent folder.
+func (jc *APIClient) createItem(item JobPath, config []byte) error {
+	apiURL := fmt.Sprintf("%s%s/createItem?name=%s", jc.baseURL(), item.Parent().URLPath(), url.QueryEscape(item.Name()))
+
+	resp, body, err := jc.doRequest("POST", apiURL, config, "application/xml")
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package jenkins
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// DefaultJobTemplate is the pipeline job config XML template used unless
+// another one is given.
+//
+//go:embed template-config.xml
+var DefaultJobTemplate string
+
+// DefaultMultibranchTemplate is the multibranch job config XML template used
+// unless another one is given.
+//
+//go:embed template-multibranch.xml
+var DefaultMultibranchTemplate string
+
+// JobType selects the kind of Jenkins job a project gets.
+type JobType string
+
+const (
+	// Pipeline is a single pipeline job building every branch in one history.
+	Pipeline JobType = "pipeline"
+	// Multibranch is a multibranch project with one job per branch and PR.
+	Multibranch JobType = "multibranch"
+)
+
+const multibranchClass = "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"
+
+// ParseJobType validates a job type name.
+func ParseJobType(s string) (JobType, error) {
+	switch t := JobType(s); t {
+	case Pipeline, Multibranch:
+		return t, nil
+	default:
+		return "", fmt.Errorf("unknown job type %q: use pipeline or multibranch", s)
+	}
+}
+
+// JobTemplateData is the data model available to job config XML templates.
+// Templates use Go text/template syntax, e.g. {{.Repo}} or {{.Vars.team}}.
+// Every value is XML-escaped before the template is executed, so templates
+// must not escape them again.
+type JobTemplateData struct {
+	Org           string            // GitHub organization that owns the repo
+	Repo          string            // GitHub repository name
+	JobName       string            // Jenkins job name, without folders
+	CredentialsID string            // Jenkins credentials used to check out the repo
+	BranchSpec    string            // Branches to build, e.g. */**
+	ScriptPath    string            // Path to the Jenkinsfile within the repo
+	Description   string            // Job description shown in Jenkins
+	Vars          map[string]string // Extra values passed with --var key=value
+
+	// Multibranch only: how long to keep jobs for deleted branches and closed
+	// PRs, -1 for no limit.
+	OrphanDaysToKeep int
+	OrphanNumToKeep  int
+}
+
+// escaped returns a copy of the data with every value XML-escaped.
+func (d JobTemplateData) escaped() JobTemplateData {
+	e := JobTemplateData{
+		Org:           xmlEscape(d.Org),
+		Repo:          xmlEscape(d.Repo),
+		JobName:       xmlEscape(d.JobName),
+		CredentialsID: xmlEscape(d.CredentialsID),
+		BranchSpec:    xmlEscape(d.BranchSpec),
+		ScriptPath:    xmlEscape(d.ScriptPath),
+		Description:   xmlEscape(d.Description),
+		Vars:          make(map[string]string, len(d.Vars)),
+
+		OrphanDaysToKeep: d.OrphanDaysToKeep,
+		OrphanNumToKeep:  d.OrphanNumToKeep,
+	}
+	for key, value := range d.Vars {
+		e.Vars[key] = xmlEscape(value)
+	}
+	return e
+}
+
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// LoadJobTemplate reads the config XML template at path, or returns the
+// embedded default for the job type if path is empty.
+func LoadJobTemplate(path string, jobType JobType) (string, error) {
+	if path == "" {
+		if jobType == Multibranch {
+			return DefaultMultibranchTemplate, nil
+		}
+		return DefaultJobTemplate, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read XML file: %v", err)
+	}
+	return string(data), nil
+}
+
+// RenderJobConfig executes the config XML template at templatePath (or the
+// embedded default for the job type if it is empty) with the given data and
+// checks that the result is well-formed XML.
+func RenderJobConfig(templatePath string, jobType JobType, data JobTemplateData) ([]byte, error) {
+	templateData, err := LoadJobTemplate(templatePath, jobType)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New("job-config").Option("missingkey=error").Parse(templateData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse job template: %v", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data.escaped()); err != nil {
+		return nil, fmt.Errorf("failed to render job template: %v", err)
+	}
+
+	if err := validateXML(rendered.Bytes()); err != nil {
+		return nil, fmt.Errorf("rendered job config is not valid XML: %v", err)
+	}
+	return rendered.Bytes(), nil
+}
+
+// validateXML checks that data is a well-formed XML document.
+func validateXML(data []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(stripXMLDecl(data)))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// RetargetRepo rewrites the GitHub repo URLs (and multibranch repository
+// names) in a job config from oldRepo to newRepo, keeping the org.
+func RetargetRepo(config []byte, oldRepo string, newRepo string) []byte {
+	old := regexp.QuoteMeta(xmlEscape(oldRepo))
+	replacement := xmlEscape(newRepo)
+
+	urlPattern := regexp.MustCompile(`(github\.com[/:][^/<\s]+/)` + old + `(\.git|/|<)`)
+	config = urlPattern.ReplaceAll(config, []byte("${1}"+regexpEscapeReplacement(replacement)+"${2}"))
+
+	repoPattern := regexp.MustCompile(`<repository>` + old + `</repository>`)
+	return repoPattern.ReplaceAll(config, []byte("<repository>"+regexpEscapeReplacement(replacement)+"</repository>"))
+}
+
+// regexpEscapeReplacement escapes $ so a literal survives Regexp.ReplaceAll.
+func regexpEscapeReplacement(s string) string {
+	return strings.ReplaceAll(s, "$", "$$")
+}
@@ -0,0 +1,79 @@
+package jenkins
+
+import "testing"
+
+func TestRetargetRepo(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		oldRepo string
+		newRepo string
+		want    string
+	}{
+		{
+			name:    "https clone URL",
+			config:  "<url>https://github.com/FortinetCloudCSE/my-repo.git</url>",
+			oldRepo: "my-repo",
+			newRepo: "other-repo",
+			want:    "<url>https://github.com/FortinetCloudCSE/other-repo.git</url>",
+		},
+		{
+			name:    "ssh clone URL",
+			config:  "<url>git@github.com:FortinetCloudCSE/my-repo.git</url>",
+			oldRepo: "my-repo",
+			newRepo: "other-repo",
+			want:    "<url>git@github.com:FortinetCloudCSE/other-repo.git</url>",
+		},
+		{
+			name:    "project URLs with and without trailing slash",
+			config:  "<projectUrl>https://github.com/FortinetCloudCSE/my-repo/</projectUrl><u>https://github.com/FortinetCloudCSE/my-repo</u>",
+			oldRepo: "my-repo",
+			newRepo: "other-repo",
+			want:    "<projectUrl>https://github.com/FortinetCloudCSE/other-repo/</projectUrl><u>https://github.com/FortinetCloudCSE/other-repo</u>",
+		},
+		{
+			name:    "multibranch repository",
+			config:  "<repoOwner>FortinetCloudCSE</repoOwner><repository>my-repo</repository>",
+			oldRepo: "my-repo",
+			newRepo: "other-repo",
+			want:    "<repoOwner>FortinetCloudCSE</repoOwner><repository>other-repo</repository>",
+		},
+		{
+			name:    "repos sharing a prefix are left alone",
+			config:  "<url>https://github.com/FortinetCloudCSE/my-repo-2.git</url><repository>my-repo-2</repository>",
+			oldRepo: "my-repo",
+			newRepo: "other-repo",
+			want:    "<url>https://github.com/FortinetCloudCSE/my-repo-2.git</url><repository>my-repo-2</repository>",
+		},
+		{
+			name:    "other hosts are left alone",
+			config:  "<url>https://gitlab.com/FortinetCloudCSE/my-repo.git</url>",
+			oldRepo: "my-repo",
+			newRepo: "other-repo",
+			want:    "<url>https://gitlab.com/FortinetCloudCSE/my-repo.git</url>",
+		},
+		{
+			name:    "dots in the old name are literal",
+			config:  "<url>https://github.com/FortinetCloudCSE/myXrepo.git</url><url>https://github.com/FortinetCloudCSE/my.repo.git</url>",
+			oldRepo: "my.repo",
+			newRepo: "other-repo",
+			want:    "<url>https://github.com/FortinetCloudCSE/myXrepo.git</url><url>https://github.com/FortinetCloudCSE/other-repo.git</url>",
+		},
+		{
+			name:    "dollar signs in the new name are literal",
+			config:  "<repository>my-repo</repository>",
+			oldRepo: "my-repo",
+			newRepo: "repo$1",
+			want:    "<repository>repo$1</repository>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(RetargetRepo([]byte(tt.config), tt.oldRepo, tt.newRepo))
+			if got != tt.want {
+				t.Errorf("RetargetRepo =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
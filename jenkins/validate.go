@@ -0,0 +1,61 @@
+package jenkins
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// JenkinsfileError is a problem Jenkins reported in a Jenkinsfile. Line and
+// Column are 0 when Jenkins did not report a position.
+type JenkinsfileError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e JenkinsfileError) String() string {
+	if e.Line == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// Matches errors like "WorkflowScript: 12: Unknown stage section "foo". @ line 12, column 5."
+var jenkinsfileErrorPattern = regexp.MustCompile(`(?m)^WorkflowScript: \d+: (.*?) @ line (\d+), column (\d+)\.\s*$`)
+
+// ValidateJenkinsfile asks Jenkins' declarative pipeline linter to validate a
+// Jenkinsfile and returns the errors it found, or nil if it is valid.
+func (jc *APIClient) ValidateJenkinsfile(content string) ([]JenkinsfileError, error) {
+	apiURL := jc.baseURL() + "/pipeline-model-converter/validate"
+	form := url.Values{"jenkinsfile": {content}}
+
+	resp, body, err := jc.doRequest("POST", apiURL, []byte(form.Encode()), "application/x-www-form-urlencoded")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Jenkins API error: %s, response: %s", resp.Status, string(body))
+	}
+
+	result := strings.TrimSpace(string(body))
+	if strings.Contains(result, "successfully validated") {
+		return nil, nil
+	}
+
+	var errs []JenkinsfileError
+	for _, match := range jenkinsfileErrorPattern.FindAllStringSubmatch(result, -1) {
+		line, _ := strconv.Atoi(match[2])
+		column, _ := strconv.Atoi(match[3])
+		errs = append(errs, JenkinsfileError{Line: line, Column: column, Message: match[1]})
+	}
+
+	if len(errs) == 0 {
+		errs = append(errs, JenkinsfileError{Message: result})
+	}
+	return errs, nil
+}
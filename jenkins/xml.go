@@ -0,0 +1,60 @@
+package jenkins
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Jenkins writes XML 1.1 declarations, which encoding/xml refuses to parse.
+var xmlDeclPattern = regexp.MustCompile(`^\s*<\?xml[^?]*\?>`)
+
+func stripXMLDecl(data []byte) []byte {
+	return xmlDeclPattern.ReplaceAll(data, nil)
+}
+
+// NormalizeXML re-indents an XML document with sorted attributes and no
+// whitespace-only text, so two configs that differ only in formatting
+// normalize to the same string.
+func NormalizeXML(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(stripXMLDecl(data)))
+
+	var out bytes.Buffer
+	encoder := xml.NewEncoder(&out)
+	encoder.Indent("", "  ")
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse XML: %v", err)
+		}
+
+		switch t := token.(type) {
+		case xml.CharData:
+			if len(bytes.TrimSpace(t)) == 0 {
+				continue
+			}
+		case xml.StartElement:
+			sort.Slice(t.Attr, func(i, j int) bool { return t.Attr[i].Name.Local < t.Attr[j].Name.Local })
+			token = t
+		case xml.Comment, xml.ProcInst, xml.Directive:
+			continue
+		}
+
+		if err := encoder.EncodeToken(token); err != nil {
+			return "", fmt.Errorf("failed to write XML: %v", err)
+		}
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return "", fmt.Errorf("failed to write XML: %v", err)
+	}
+	return strings.TrimSpace(out.String()) + "\n", nil
+}
@@ -3,3 +3,11 @@ export GITHUB_TOKEN=mytoken123
 export JENKINS_URL=https://jenkinsurl.com:8443
 export JENKINS_USER_ID=myusername
 export JENKINS_API_TOKEN=abcd1234
+
+# Optional: GitHub org and template repo used when --org/--template-* are not given.
+export GITHUB_ORG=FortinetCloudCSE
+export GITHUB_TEMPLATE_OWNER=FortinetCloudCSE
+export GITHUB_TEMPLATE_REPO=UserRepo
+
+# Optional: secret signing the Jenkins webhooks of new repos. Generated on first use if unset.
+# export GITHUB_WEBHOOK_SECRET=mysecret